@@ -0,0 +1,97 @@
+package pgxscan
+
+import (
+	"context"
+
+	"github.com/Oliver-Fish/pgxscan/internal/mapping"
+	"github.com/jackc/pgx/v4"
+)
+
+type batchSender interface {
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+}
+
+//Batch queues queries on a pgx.Batch together with the destinations their results are scanned into
+//The zero value is ready to use
+type Batch struct {
+	batch pgx.Batch
+	items []batchItem
+}
+
+type batchItem struct {
+	input    interface{}
+	query    string
+	multiRow bool
+}
+
+//QueueRow queues a query whose single row is scanned into input, a pointer to a struct
+//The result follows the same rules as QueryRow
+func (b *Batch) QueueRow(input interface{}, query string, args ...interface{}) {
+	b.queue(input, false, query, args)
+}
+
+//QueueRows queues a query whose rows are scanned into input, a pointer to a slice of struct
+//The result follows the same rules as Rows
+func (b *Batch) QueueRows(input interface{}, query string, args ...interface{}) {
+	b.queue(input, true, query, args)
+}
+
+func (b *Batch) queue(input interface{}, multiRow bool, query string, args []interface{}) {
+	b.batch.Queue(query, args...)
+	b.items = append(b.items, batchItem{
+		input:    input,
+		query:    query,
+		multiRow: multiRow,
+	})
+}
+
+//Len returns the number of queued queries
+func (b *Batch) Len() int {
+	return len(b.items)
+}
+
+//SendBatch sends every queued query in a single round trip and scans each result into its destination
+//If any item fails an ErrBatch is returned holding the error of each failed item
+func SendBatch(ctx context.Context, tx batchSender, b *Batch) error {
+	results := tx.SendBatch(ctx, &b.batch)
+
+	var batchErr ErrBatch
+	for i, item := range b.items {
+		err := scanBatchItem(results, item)
+		if err != nil {
+			batchErr.Items = append(batchErr.Items, ErrBatchItem{
+				Index: i,
+				Query: item.query,
+				Err:   err,
+			})
+		}
+	}
+
+	err := results.Close()
+	if len(batchErr.Items) > 0 {
+		return batchErr
+	}
+
+	return err
+}
+
+func scanBatchItem(results pgx.BatchResults, item batchItem) error {
+	//Results have to be read in order, so the query result is read
+	//before the input is validated to keep the following items aligned
+	rows, err := results.Query()
+	if err != nil {
+		return err
+	}
+
+	if item.multiRow {
+		return Rows(rows, item.input)
+	}
+
+	rv, dbTagPos, err := mapping.ValidateRowInput(item.input)
+	if err != nil {
+		rows.Close()
+		return err
+	}
+
+	return scanRow(rows, item.input, rv, dbTagPos)
+}
@@ -0,0 +1,53 @@
+package pgxscan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendBatch(t *testing.T) {
+	type testStruct struct {
+		A string `db:"a"`
+		B int    `db:"b"`
+	}
+
+	var row testStruct
+	var rows []testStruct
+	var partialRow testStruct
+	var missingRow testStruct
+
+	var b Batch
+	b.QueueRow(&row, `SELECT 'a' as a, 1 as b`)
+	b.QueueRows(&rows, `SELECT 'a' as a, g as b FROM generate_series(1, 3) g`)
+	b.QueueRow(&partialRow, `SELECT 'a' as a`)
+	b.QueueRow(&missingRow, `SELECT 'a' as a, 1 as b WHERE false`)
+	require.Equal(t, 4, b.Len())
+
+	ctx := context.Background()
+	err := SendBatch(ctx, db, &b)
+	require.Equal(t, ErrBatch{
+		Items: []ErrBatchItem{
+			{
+				Index: 2,
+				Query: `SELECT 'a' as a`,
+				Err:   ErrQueryColumnsTagsMismtach,
+			},
+			{
+				Index: 3,
+				Query: `SELECT 'a' as a, 1 as b WHERE false`,
+				Err:   pgx.ErrNoRows,
+			},
+		},
+	}, err)
+
+	require.Equal(t, testStruct{A: "a", B: 1}, row)
+	require.Equal(t, []testStruct{
+		{A: "a", B: 1},
+		{A: "a", B: 2},
+		{A: "a", B: 3},
+	}, rows)
+	require.Equal(t, testStruct{A: "a"}, partialRow)
+}
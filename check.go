@@ -0,0 +1,97 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/Oliver-Fish/pgxscan/internal/mapping"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgproto3/v2"
+)
+
+type preparer interface {
+	Prepare(ctx context.Context, name, sql string) (*pgconn.StatementDescription, error)
+}
+
+//Check validates the db tags of input against the result columns of query without executing it
+//input is a pointer to a struct or a pointer to a slice of struct as passed to QueryRow or Query
+//The statement is only described by the server, mismatches are returned as an ErrMappingMismatch
+//*pgxpool.Pool can not prepare statements, acquire a connection and pass its *pgx.Conn instead
+func Check(ctx context.Context, conn preparer, input interface{}, query string) error {
+	rt, err := destinationStruct(input)
+	if err != nil {
+		return err
+	}
+
+	dbTagPos, err := mapping.ReadTagPositions(rt)
+	if err != nil {
+		return err
+	}
+
+	sd, err := conn.Prepare(ctx, "", query)
+	if err != nil {
+		return err
+	}
+
+	return checkFields(fmt.Sprintf("%T", input), rt, dbTagPos, sd.Fields)
+}
+
+//destinationStruct returns the struct type of a pointer to a struct or a pointer to a slice of struct
+func destinationStruct(input interface{}) (reflect.Type, error) {
+	rt := reflect.TypeOf(input)
+	if rt == nil || rt.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("input value is not a pointer")
+	}
+
+	rt = rt.Elem()
+	if rt.Kind() == reflect.Slice {
+		rt = rt.Elem()
+	}
+	if rt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("input value is not a pointer to a struct or a slice of struct")
+	}
+
+	return rt, nil
+}
+
+//checkFields compares the described result fields with the db tags of rt
+func checkFields(valueType string, rt reflect.Type, dbTagPos map[string][]int, fields []pgproto3.FieldDescription) error {
+	mismatch := ErrMappingMismatch{
+		ValueType: valueType,
+	}
+
+	seen := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		column := string(field.Name)
+		seen[column] = true
+
+		fieldPos, ok := dbTagPos[column]
+		if !ok {
+			mismatch.ExtraColumns = append(mismatch.ExtraColumns, column)
+			continue
+		}
+
+		structField := rt.FieldByIndex(fieldPos)
+		if !compatibleType(field.DataTypeOID, structField.Type) {
+			mismatch.TypeMismatches = append(mismatch.TypeMismatches, TypeMismatch{
+				Column:     column,
+				ColumnType: pgTypeName(field.DataTypeOID),
+				Field:      structField.Name,
+				FieldType:  structField.Type.String(),
+			})
+		}
+	}
+
+	for _, tag := range mapping.SortedDBTags(dbTagPos) {
+		if !seen[tag] {
+			mismatch.MissingColumns = append(mismatch.MissingColumns, tag)
+		}
+	}
+
+	if len(mismatch.MissingColumns) == 0 && len(mismatch.ExtraColumns) == 0 && len(mismatch.TypeMismatches) == 0 {
+		return nil
+	}
+
+	return mismatch
+}
@@ -0,0 +1,101 @@
+package pgxscan
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	type testStruct struct {
+		A string    `db:"a"`
+		B *int      `db:"b"`
+		C time.Time `db:"c"`
+		D string    `db:"-"`
+	}
+
+	tests := map[string]struct {
+		query         string
+		expectedError error
+	}{
+		"Matching": {
+			query: `SELECT 'a'::text as a, 1 as b, now() as c`,
+		},
+		"Missing and extra columns": {
+			query: `SELECT 'a'::text as a, 1 as b, 'd'::text as d`,
+			expectedError: ErrMappingMismatch{
+				ValueType:      "*pgxscan.testStruct",
+				MissingColumns: []string{"c"},
+				ExtraColumns:   []string{"d"},
+			},
+		},
+		"Type mismatch": {
+			query: `SELECT 1 as a, 1 as b, now() as c`,
+			expectedError: ErrMappingMismatch{
+				ValueType: "*pgxscan.testStruct",
+				TypeMismatches: []TypeMismatch{
+					{
+						Column:     "a",
+						ColumnType: "int4",
+						Field:      "A",
+						FieldType:  "string",
+					},
+				},
+			},
+		},
+		"Not executed": {
+			query: `SELECT 'a'::text as a, 1 / 0 as b, now() as c`,
+		},
+	}
+
+	ctx := context.Background()
+	conn, err := db.Acquire(ctx)
+	require.NoError(t, err)
+	defer conn.Release()
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var val testStruct
+			err := Check(ctx, conn.Conn(), &val, tc.query)
+			require.Equal(t, tc.expectedError, err)
+		})
+	}
+}
+
+func TestCompatibleType(t *testing.T) {
+	type scanner struct {
+		pgtype.Text
+	}
+	type blob []byte
+
+	tests := map[string]struct {
+		oid        uint32
+		value      interface{}
+		compatible bool
+	}{
+		"Text into string":         {oid: pgtype.TextOID, value: "", compatible: true},
+		"Text into string pointer": {oid: pgtype.TextOID, value: stringPtr(""), compatible: true},
+		"Int into string":          {oid: pgtype.Int4OID, value: "", compatible: false},
+		"Numeric into float":       {oid: pgtype.NumericOID, value: float64(0), compatible: true},
+		"Timestamptz into time":    {oid: pgtype.TimestamptzOID, value: time.Time{}, compatible: true},
+		"Timestamptz into int":     {oid: pgtype.TimestamptzOID, value: 0, compatible: false},
+		"Array into slice":         {oid: pgtype.TextArrayOID, value: []string{}, compatible: true},
+		"Array into bytes":         {oid: pgtype.TextArrayOID, value: []byte{}, compatible: false},
+		"Bytea into raw message":   {oid: pgtype.ByteaOID, value: json.RawMessage{}, compatible: true},
+		"Text into named bytes":    {oid: pgtype.TextOID, value: blob{}, compatible: true},
+		"Array into named bytes":   {oid: pgtype.TextArrayOID, value: blob{}, compatible: false},
+		"Decoder":                  {oid: pgtype.Int4OID, value: scanner{}, compatible: true},
+		"Unknown type":             {oid: 999999, value: 0, compatible: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.compatible, compatibleType(tc.oid, reflect.TypeOf(tc.value)))
+		})
+	}
+}
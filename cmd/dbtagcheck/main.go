@@ -0,0 +1,16 @@
+//Command dbtagcheck runs the dbtagcheck analyzer, reporting destinations passed to pgxscan that fail at runtime
+//
+//It runs on its own or through go vet:
+//
+//	dbtagcheck ./...
+//	go vet -vettool=$(which dbtagcheck) ./...
+package main
+
+import (
+	"github.com/Oliver-Fish/pgxscan/dbtagcheck"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(dbtagcheck.Analyzer)
+}
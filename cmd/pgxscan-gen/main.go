@@ -0,0 +1,290 @@
+//Command pgxscan-gen generates reflection free scanners for db tagged structs
+//
+//Add a directive next to the structs and run go generate:
+//
+//	//go:generate pgxscan-gen -type User,Order
+//
+//The generated file registers a scanner for each type with pgxscan.RegisterScanner in an init function,
+//pgxscan.Rows and pgxscan.QueryRow then use it instead of reflection
+//The scanners keep the tag semantics of pgxscan: nested structs, pointer allocation and mismatch errors
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Oliver-Fish/pgxscan"
+	"golang.org/x/tools/go/packages"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma separated list of struct type names, required")
+	output := flag.String("output", "pgxscan_gen.go", "output file name, relative paths are resolved against the package directory")
+	flag.Parse()
+
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	src, err := generate(dir, strings.Split(*typeNames, ","))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "pgxscan-gen: %s\n", err)
+		os.Exit(1)
+	}
+
+	path := *output
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	err = os.WriteFile(path, src, 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "pgxscan-gen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+//step is a field on the path to a tagged field
+type step struct {
+	name string
+	//alloc is the type allocated when the field is a nil pointer to a struct, empty for non pointer fields
+	alloc    string
+	exported bool
+}
+
+//column is a db tagged field and the path to it
+type column struct {
+	tag       string
+	path      []step
+	writeonly bool
+}
+
+//generate returns the formatted scanners for typeNames in the package in dir
+func generate(dir string, typeNames []string) ([]byte, error) {
+	pkgs, err := packages.Load(&packages.Config{
+		//Dependencies are type checked from source so export data of any Go version can be read
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
+		Dir:  dir,
+	}, ".")
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected a single package in %s, found %d", dir, len(pkgs))
+	}
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return nil, pkg.Errors[0]
+	}
+
+	imports := map[string]string{
+		"github.com/Oliver-Fish/pgxscan": "pgxscan",
+	}
+	qualifier := func(other *types.Package) string {
+		if other == pkg.Types {
+			return ""
+		}
+		imports[other.Path()] = other.Name()
+		return other.Name()
+	}
+
+	var body bytes.Buffer
+	for _, typeName := range typeNames {
+		obj := pkg.Types.Scope().Lookup(strings.TrimSpace(typeName))
+		if obj == nil {
+			return nil, fmt.Errorf("type %s not found in package %s", typeName, pkg.PkgPath)
+		}
+		named, ok := obj.Type().(*types.Named)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a named type", typeName)
+		}
+		if named.TypeParams().Len() > 0 {
+			return nil, fmt.Errorf("%s is a generic type", typeName)
+		}
+		st, ok := named.Underlying().(*types.Struct)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a struct", typeName)
+		}
+
+		columns, err := collectColumns(st, named.String(), nil, qualifier)
+		if err != nil {
+			return nil, err
+		}
+
+		writeScanner(&body, imports, obj.Name(), readColumns(columns))
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by pgxscan-gen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg.Name)
+	paths := make([]string, 0, len(imports))
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintf(&src, "\t%q\n", path)
+	}
+	fmt.Fprintf(&src, ")\n\nfunc init() {\n%s}\n", body.Bytes())
+
+	return format.Source(src.Bytes())
+}
+
+//collectColumns walks st the way pgxscan does at runtime and returns its tagged fields
+//A tag used more than once keeps its last field, as the runtime mapping does
+func collectColumns(st *types.Struct, structName string, prefix []step, qualifier types.Qualifier) ([]column, error) {
+	var columns []column
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		tag, options := pgxscan.ParseTag(reflect.StructTag(st.Tag(i)).Get("db"))
+		path := append(append([]step{}, prefix...), step{
+			name:     field.Name(),
+			exported: field.Exported(),
+		})
+
+		var nested *types.Struct
+		switch underlying := field.Type().Underlying().(type) {
+		case *types.Struct:
+			nested = underlying
+		case *types.Pointer:
+			if st, ok := underlying.Elem().Underlying().(*types.Struct); ok {
+				nested = st
+				path[len(path)-1].alloc = types.TypeString(underlying.Elem(), qualifier)
+			}
+		}
+
+		switch {
+		case tag == "-":
+			continue
+		case tag != "":
+			err := pgxscan.CheckTagOptions(options)
+			if err != nil {
+				return nil, fmt.Errorf("db tag of field %s of struct %s: %s", field.Name(), structName, err)
+			}
+
+			//Tag Found so add it to the list and don't go deeper
+			path[len(path)-1].alloc = ""
+			columns = append(columns, column{
+				tag:       tag,
+				path:      path,
+				writeonly: contains(options, "writeonly"),
+			})
+		case nested != nil:
+			nestedColumns, err := collectColumns(nested, field.Type().String(), path, qualifier)
+			if err != nil {
+				return nil, err
+			}
+			columns = append(columns, nestedColumns...)
+		default:
+			return nil, fmt.Errorf("unset tag on property %d of struct %s", i, structName)
+		}
+	}
+
+	//Keep the last field of repeated tags
+	seen := make(map[string]bool, len(columns))
+	unique := columns[:0:0]
+	for i := len(columns) - 1; i >= 0; i-- {
+		if !seen[columns[i].tag] {
+			seen[columns[i].tag] = true
+			unique = append([]column{columns[i]}, unique...)
+		}
+	}
+
+	return unique, nil
+}
+
+//readColumns returns the columns scanned into, leaving out the fields tagged writeonly
+func readColumns(columns []column) []column {
+	var read []column
+	for _, c := range columns {
+		if !c.writeonly {
+			read = append(read, c)
+		}
+	}
+
+	return read
+}
+
+func contains(options []string, option string) bool {
+	for _, o := range options {
+		if o == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+//writeScanner writes the RegisterScanner call for typeName
+func writeScanner(w *bytes.Buffer, imports map[string]string, typeName string, columns []column) {
+	fmt.Fprintf(w, "pgxscan.RegisterScanner((*%s)(nil), pgxscan.GeneratedScanner{\n", typeName)
+	fmt.Fprintf(w, "Tags: %d,\n", len(columns))
+	fmt.Fprintf(w, "Bind: func(column string) (func(dest interface{}) interface{}, bool, error) {\nswitch column {\n")
+	for _, c := range columns {
+		fmt.Fprintf(w, "case %q:\n", c.tag)
+		writeBind(w, imports, typeName, c)
+	}
+	fmt.Fprintf(w, "}\nreturn nil, false, nil\n},\n")
+	fmt.Fprintf(w, "New: func() interface{} {\nreturn new(%s)\n},\n", typeName)
+	fmt.Fprintf(w, "Len: func(slice interface{}) int {\nreturn len(*slice.(*[]%s))\n},\n", typeName)
+	fmt.Fprintf(w, "Index: func(slice interface{}, i int) interface{} {\nreturn &(*slice.(*[]%s))[i]\n},\n", typeName)
+	fmt.Fprintf(w, "Set: func(slice interface{}, values []interface{}) {\ns := make([]%s, len(values))\n", typeName)
+	fmt.Fprintf(w, "for i, v := range values {\ns[i] = *v.(*%s)\n}\n*slice.(*[]%s) = s\n},\n})\n", typeName, typeName)
+}
+
+//writeBind writes the case body binding the field of c
+//Unexported fields return the same errors as the reflection path
+func writeBind(w *bytes.Buffer, imports map[string]string, typeName string, c column) {
+	last := c.path[len(c.path)-1]
+	if !last.exported {
+		fmt.Fprintf(w, "return nil, true, pgxscan.ErrUnexportedProperty{PropertyName: %q}\n", last.name)
+		return
+	}
+	for _, s := range c.path {
+		if !s.exported {
+			imports["errors"] = "errors"
+			fmt.Fprintf(w, "return nil, true, errors.New(\"unable to convert pointer of field to interface\")\n")
+			return
+		}
+	}
+
+	fmt.Fprintf(w, "return func(dest interface{}) interface{} {\n")
+	selector := "d"
+	allocates := false
+	for _, s := range c.path[:len(c.path)-1] {
+		if s.alloc != "" {
+			allocates = true
+		}
+	}
+
+	if !allocates {
+		names := make([]string, len(c.path))
+		for i, s := range c.path {
+			names[i] = s.name
+		}
+		fmt.Fprintf(w, "return &dest.(*%s).%s\n}, true, nil\n", typeName, strings.Join(names, "."))
+		return
+	}
+
+	fmt.Fprintf(w, "d := dest.(*%s)\n", typeName)
+	for _, s := range c.path[:len(c.path)-1] {
+		selector += "." + s.name
+		if s.alloc != "" {
+			fmt.Fprintf(w, "if %s == nil {\n%s = new(%s)\n}\n", selector, selector, s.alloc)
+		}
+	}
+	fmt.Fprintf(w, "return &%s.%s\n}, true, nil\n", selector, last.name)
+}
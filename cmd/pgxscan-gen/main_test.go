@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := map[string]struct {
+		typeNames []string
+		golden    string
+		err       string
+	}{
+		"Models": {
+			typeNames: []string{"User", "Address"},
+			golden:    "testdata/models.golden",
+		},
+		"MissingType": {
+			typeNames: []string{"Missing"},
+			err:       "type Missing not found in package github.com/Oliver-Fish/pgxscan/cmd/pgxscan-gen/testdata/models",
+		},
+		"InvalidTagOption": {
+			typeNames: []string{"Invalid"},
+			err:       `db tag of field ID of struct github.com/Oliver-Fish/pgxscan/cmd/pgxscan-gen/testdata/models.Invalid: unknown option "primary"`,
+		},
+		"NotAStruct": {
+			typeNames: []string{"Status"},
+			err:       "Status is not a struct",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			src, err := generate("testdata/models", tc.typeNames)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+
+			expected, err := os.ReadFile(tc.golden)
+			require.NoError(t, err)
+			require.Equal(t, string(expected), string(src))
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+type Address struct {
+	Street string `db:"street"`
+}
+
+type Profile struct {
+	Bio *string `db:"bio"`
+	*Address
+}
+
+type User struct {
+	ID        int            `db:"id,pk"`
+	Name      string         `db:"name"`
+	CreatedAt time.Time      `db:"created_at"`
+	Email     sql.NullString `db:"email"`
+	Profile   Profile
+	Manager   *Profile `db:"-"`
+	Extra     *struct {
+		Note string `db:"note"`
+	}
+	secret string `db:"secret"`
+	Token  string `db:"token,writeonly"`
+}
+
+type Status string
+
+type Invalid struct {
+	ID int `db:"id,primary"`
+}
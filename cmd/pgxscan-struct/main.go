@@ -0,0 +1,279 @@
+//Command pgxscan-struct writes a Go struct matching the result columns of a query or table
+//
+//	pgxscan-struct -dsn postgres://localhost/app -name User -table users
+//	pgxscan-struct -name UserOrder -query 'SELECT u.id, o.total FROM users u JOIN orders o ON o.user_id = u.id'
+//
+//The query is described, not executed. Columns of a table that can be NULL become pointers,
+//columns that aren't read from a table such as expressions are assumed to be nullable
+//Columns of outer joined tables keep the nullability of their table and should be reviewed
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Oliver-Fish/pgxscan"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+)
+
+func main() {
+	dsn := flag.String("dsn", os.Getenv("DATABASE_URL"), "connection string of the database, defaults to $DATABASE_URL")
+	query := flag.String("query", "", "query whose result columns are described")
+	table := flag.String("table", "", "table whose columns are described, may be schema qualified")
+	name := flag.String("name", "", "name of the struct, required")
+	pkg := flag.String("package", "models", "package clause of the output")
+	output := flag.String("output", "", "output file name, defaults to stdout")
+	flag.Parse()
+
+	if *name == "" || (*query == "") == (*table == "") {
+		fmt.Fprintln(os.Stderr, "pgxscan-struct: -name and one of -query or -table are required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	source := *query
+	if *table != "" {
+		source = "SELECT * FROM " + pgx.Identifier(strings.Split(*table, ".")).Sanitize()
+	}
+
+	src, err := run(context.Background(), *dsn, source, *pkg, *name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "pgxscan-struct: %s\n", err)
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		_, err = os.Stdout.Write(src)
+	} else {
+		err = os.WriteFile(*output, src, 0644)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "pgxscan-struct: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, dsn, query, pkg, name string) ([]byte, error) {
+	conn, err := pgx.Connect(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close(ctx)
+
+	columns, err := describe(ctx, conn, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return writeStruct(pkg, name, query, columns)
+}
+
+//column is a result column of the described query
+type column struct {
+	Name     string
+	OID      uint32
+	Nullable bool
+}
+
+//attribute is a column of a table as stored in pg_attribute
+type attribute struct {
+	Num     int16 `db:"attnum"`
+	NotNull bool  `db:"attnotnull"`
+}
+
+//describe returns the result columns of query
+func describe(ctx context.Context, conn *pgx.Conn, query string) ([]column, error) {
+	sd, err := conn.Prepare(ctx, "", query)
+	if err != nil {
+		return nil, err
+	}
+
+	//Not null constraints of every table the columns are read from, keyed by table and column number
+	notNull := map[uint32]map[int16]bool{}
+	columns := make([]column, len(sd.Fields))
+	for i, field := range sd.Fields {
+		columns[i] = column{
+			Name:     string(field.Name),
+			OID:      field.DataTypeOID,
+			Nullable: true,
+		}
+
+		if field.TableOID == 0 {
+			continue
+		}
+
+		attributes, ok := notNull[field.TableOID]
+		if !ok {
+			var rows []attribute
+			err = pgxscan.Query(ctx, conn, &rows, `
+				SELECT attnum, attnotnull
+				FROM pg_attribute
+				WHERE attrelid = $1 AND attnum > 0
+			`, field.TableOID)
+			if err != nil {
+				return nil, err
+			}
+
+			attributes = make(map[int16]bool, len(rows))
+			for _, row := range rows {
+				attributes[row.Num] = row.NotNull
+			}
+			notNull[field.TableOID] = attributes
+		}
+		columns[i].Nullable = !attributes[int16(field.TableAttributeNumber)]
+	}
+
+	return columns, nil
+}
+
+//writeStruct returns the formatted source of the struct name with a field for each column
+func writeStruct(pkg, name, query string, columns []column) ([]byte, error) {
+	imports := map[string]bool{}
+	seen := map[string]bool{}
+
+	var fields bytes.Buffer
+	for _, c := range columns {
+		if seen[c.Name] {
+			return nil, fmt.Errorf("column %s is returned more than once, alias the columns so each name is unique", c.Name)
+		}
+		seen[c.Name] = true
+
+		fieldName, err := fieldName(c.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		goType, importPath := goType(c.OID)
+		if importPath != "" {
+			imports[importPath] = true
+		}
+		if c.Nullable && nullablePointer(goType) {
+			goType = "*" + goType
+		}
+
+		fmt.Fprintf(&fields, "%s %s `db:%q`\n", fieldName, goType, c.Name)
+	}
+
+	var w bytes.Buffer
+	fmt.Fprintf(&w, "package %s\n\n", pkg)
+	if len(imports) > 0 {
+		paths := make([]string, 0, len(imports))
+		for path := range imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		fmt.Fprintf(&w, "import (\n")
+		for _, path := range paths {
+			fmt.Fprintf(&w, "%q\n", path)
+		}
+		fmt.Fprintf(&w, ")\n\n")
+	}
+
+	//The comment is written the way gofmt formats doc comments, as the output lands in user code
+	fmt.Fprintf(&w, "// %s is generated by pgxscan-struct from:\n//\n", name)
+	for _, line := range strings.Split(strings.TrimSpace(query), "\n") {
+		fmt.Fprintf(&w, "//\t%s\n", strings.TrimSpace(line))
+	}
+	fmt.Fprintf(&w, "type %s struct {\n%s}\n", name, fields.Bytes())
+
+	return format.Source(w.Bytes())
+}
+
+//goType returns the Go type a column of the PostgreSQL type oid is scanned into and the package it needs
+//Types without a rule are scanned as their text representation
+func goType(oid uint32) (string, string) {
+	switch oid {
+	case pgtype.BoolOID:
+		return "bool", ""
+	case pgtype.Int2OID:
+		return "int16", ""
+	case pgtype.Int4OID:
+		return "int32", ""
+	case pgtype.Int8OID:
+		return "int64", ""
+	case pgtype.OIDOID:
+		return "uint32", ""
+	case pgtype.Float4OID:
+		return "float32", ""
+	case pgtype.Float8OID, pgtype.NumericOID:
+		return "float64", ""
+	case pgtype.ByteaOID, pgtype.JSONOID, pgtype.JSONBOID:
+		return "[]byte", ""
+	case pgtype.DateOID, pgtype.TimestampOID, pgtype.TimestamptzOID:
+		return "time.Time", "time"
+	case pgtype.IntervalOID:
+		return "time.Duration", "time"
+	case pgtype.BoolArrayOID:
+		return "[]bool", ""
+	case pgtype.Int2ArrayOID:
+		return "[]int16", ""
+	case pgtype.Int4ArrayOID:
+		return "[]int32", ""
+	case pgtype.Int8ArrayOID:
+		return "[]int64", ""
+	case pgtype.Float4ArrayOID:
+		return "[]float32", ""
+	case pgtype.Float8ArrayOID, pgtype.NumericArrayOID:
+		return "[]float64", ""
+	case pgtype.TextArrayOID, pgtype.VarcharArrayOID, pgtype.BPCharArrayOID, pgtype.UUIDArrayOID:
+		return "[]string", ""
+	case pgtype.TimestampArrayOID, pgtype.TimestamptzArrayOID, pgtype.DateArrayOID:
+		return "[]time.Time", "time"
+	}
+
+	return "string", ""
+}
+
+//nullablePointer reports whether a nullable column of goType needs a pointer
+//Slices are already nil for NULL
+func nullablePointer(goType string) bool {
+	return !strings.HasPrefix(goType, "[]")
+}
+
+//initialisms are written in upper case in field names
+var initialisms = map[string]bool{
+	"API": true, "HTML": true, "HTTP": true, "ID": true, "IP": true, "JSON": true,
+	"SQL": true, "URI": true, "URL": true, "UUID": true,
+}
+
+//fieldName returns the exported Go name of the column name
+func fieldName(name string) (string, error) {
+	//?column? is the name PostgreSQL gives to columns without one
+	if name == "?column?" {
+		return "", fmt.Errorf("a column has no name, alias it in the query")
+	}
+
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+	for _, word := range words {
+		upper := strings.ToUpper(word)
+		if initialisms[upper] {
+			b.WriteString(upper)
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(word)
+		b.WriteRune(unicode.ToUpper(r))
+		b.WriteString(word[size:])
+	}
+
+	field := b.String()
+	if !token.IsIdentifier(field) || !token.IsExported(field) {
+		return "", fmt.Errorf("column %q has no valid field name, alias it in the query", name)
+	}
+
+	return field, nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/jackc/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteStruct(t *testing.T) {
+	tests := map[string]struct {
+		columns  []column
+		expected string
+		err      string
+	}{
+		"Columns": {
+			columns: []column{
+				{Name: "id", OID: pgtype.Int8OID},
+				{Name: "user_name", OID: pgtype.TextOID},
+				{Name: "bio", OID: pgtype.TextOID, Nullable: true},
+				{Name: "created_at", OID: pgtype.TimestamptzOID, Nullable: true},
+				{Name: "tags", OID: pgtype.TextArrayOID, Nullable: true},
+				{Name: "avatar_url", OID: pgtype.ByteaOID},
+				{Name: "status", OID: 100000},
+			},
+			expected: `package models
+
+import (
+	"time"
+)
+
+// User is generated by pgxscan-struct from:
+//
+//	SELECT *
+//	FROM users
+type User struct {
+	ID        int64      ` + "`db:\"id\"`" + `
+	UserName  string     ` + "`db:\"user_name\"`" + `
+	Bio       *string    ` + "`db:\"bio\"`" + `
+	CreatedAt *time.Time ` + "`db:\"created_at\"`" + `
+	Tags      []string   ` + "`db:\"tags\"`" + `
+	AvatarURL []byte     ` + "`db:\"avatar_url\"`" + `
+	Status    string     ` + "`db:\"status\"`" + `
+}
+`,
+		},
+		"DuplicateColumn": {
+			columns: []column{
+				{Name: "id", OID: pgtype.Int8OID},
+				{Name: "id", OID: pgtype.Int8OID},
+			},
+			err: "column id is returned more than once, alias the columns so each name is unique",
+		},
+		"UnnamedColumn": {
+			columns: []column{
+				{Name: "?column?", OID: pgtype.Int4OID},
+			},
+			err: "a column has no name, alias it in the query",
+		},
+		"InvalidName": {
+			columns: []column{
+				{Name: "1st", OID: pgtype.Int4OID},
+			},
+			err: `column "1st" has no valid field name, alias it in the query`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			src, err := writeStruct("models", "User", "SELECT *\n\tFROM users", tc.columns)
+			if tc.err != "" {
+				require.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(src))
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package pgxscan
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Oliver-Fish/pgxscan/internal/mapping"
+	"github.com/jackc/pgx/v4"
+)
+
+//Columns returns the quoted, comma separated db tags of input in field order, ready for a SELECT list
+//input is a pointer to a struct or a pointer to a slice of struct, tags of nested structs are included
+//and fields tagged writeonly are left out
+func Columns(input interface{}) (string, error) {
+	return ColumnsWithAlias(input, "")
+}
+
+//ColumnsWithAlias is Columns with every column qualified by the table alias
+//The result column names stay the db tags so the list can be scanned back into input
+func ColumnsWithAlias(input interface{}, alias string) (string, error) {
+	rt, err := destinationStruct(input)
+	if err != nil {
+		return "", err
+	}
+
+	return columnList(rt, alias)
+}
+
+//SelectAll returns a SELECT of every db tagged column of T from table, table may be schema qualified
+//Rows of the query scan into T without a column mismatch
+//If a field of T is tagged softdelete the query leaves out soft deleted rows unless WithDeleted is passed
+func SelectAll[T any](table string, opts ...Option) (string, error) {
+	rt := reflect.TypeOf((*T)(nil)).Elem()
+	if rt.Kind() != reflect.Struct {
+		return "", fmt.Errorf("type %s is not a struct", rt.String())
+	}
+
+	columns, err := columnList(rt, "")
+	if err != nil {
+		return "", err
+	}
+
+	query := "SELECT " + columns + " FROM " + tableIdentifier(table)
+
+	dbTagPos, err := mapping.DBTagPositions(rt)
+	if err != nil {
+		return "", err
+	}
+
+	softDelete, err := softDeleteColumn(rt, dbTagPos)
+	if err != nil {
+		return "", err
+	}
+
+	if condition := notDeleted(softDelete, mergeOptions(opts)); condition != "" {
+		query += " WHERE " + condition
+	}
+
+	return query, nil
+}
+
+//columnList returns the quoted db tags of the struct type rt, qualified by alias when set
+func columnList(rt reflect.Type, alias string) (string, error) {
+	dbTagPos, err := mapping.ReadTagPositions(rt)
+	if err != nil {
+		return "", err
+	}
+
+	tags := mapping.SortedDBTags(dbTagPos)
+	columns := make([]string, len(tags))
+	for i, tag := range tags {
+		if alias != "" {
+			columns[i] = pgx.Identifier{alias, tag}.Sanitize()
+			continue
+		}
+		columns[i] = pgx.Identifier{tag}.Sanitize()
+	}
+
+	return strings.Join(columns, ", "), nil
+}
+
+//tableIdentifier quotes table, a table name that may be schema qualified
+func tableIdentifier(table string) string {
+	return pgx.Identifier(strings.Split(table, ".")).Sanitize()
+}
@@ -0,0 +1,101 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type columnsTestNested struct {
+	C string `db:"c"`
+}
+
+type columnsTestStruct struct {
+	A string `db:"a"`
+	B *int   `db:"b"`
+	I string `db:"-"`
+	columnsTestNested
+	D struct {
+		E string `db:"e"`
+	}
+}
+
+func TestColumns(t *testing.T) {
+	tests := map[string]struct {
+		input    interface{}
+		alias    string
+		expected string
+		err      error
+	}{
+		"Struct": {
+			input:    &columnsTestStruct{},
+			expected: `"a", "b", "c", "e"`,
+		},
+		"Slice": {
+			input:    &[]columnsTestStruct{},
+			expected: `"a", "b", "c", "e"`,
+		},
+		"Alias": {
+			input:    &columnsTestStruct{},
+			alias:    "t",
+			expected: `"t"."a", "t"."b", "t"."c", "t"."e"`,
+		},
+		"QuotedTag": {
+			input: &struct {
+				A string `db:"Mixed Case"`
+			}{},
+			expected: `"Mixed Case"`,
+		},
+		"UntaggedField": {
+			input: &struct {
+				A string
+			}{},
+			err: fmt.Errorf("unset tag on property 0 of struct struct { A string }"),
+		},
+		"NotPointer": {
+			input: columnsTestStruct{},
+			err:   fmt.Errorf("input value is not a pointer"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			columns, err := ColumnsWithAlias(tc.input, tc.alias)
+			require.Equal(t, tc.err, err)
+			require.Equal(t, tc.expected, columns)
+		})
+	}
+}
+
+func TestSelectAll(t *testing.T) {
+	query, err := SelectAll[columnsTestStruct]("public.select_all")
+	require.NoError(t, err)
+	require.Equal(t, `SELECT "a", "b", "c", "e" FROM "public"."select_all"`, query)
+
+	_, err = SelectAll[int]("select_all")
+	require.EqualError(t, err, "type int is not a struct")
+
+	ctx := context.Background()
+	_, err = db.Exec(ctx, `CREATE TABLE select_all (a text, b int, c text, e text, extra text)`)
+	require.NoError(t, err)
+	defer db.Exec(ctx, `DROP TABLE select_all`)
+
+	_, err = db.Exec(ctx, `INSERT INTO select_all VALUES ('a', 1, 'c', 'e', 'extra')`)
+	require.NoError(t, err)
+
+	var rows []columnsTestStruct
+	err = Query(ctx, db, &rows, query)
+	require.NoError(t, err)
+	require.Equal(t, []columnsTestStruct{
+		{
+			A:                 "a",
+			B:                 intPtr(1),
+			columnsTestNested: columnsTestNested{C: "c"},
+			D: struct {
+				E string `db:"e"`
+			}{E: "e"},
+		},
+	}, rows)
+}
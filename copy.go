@@ -0,0 +1,211 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Oliver-Fish/pgxscan/internal/mapping"
+	"github.com/jackc/pgx/v4"
+)
+
+type copier interface {
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+//StructIterator streams structs to CopyStructs without materializing them in a slice
+//Value returns the current struct or pointer to struct, every value must be of the same type
+type StructIterator interface {
+	Next() bool
+	Value() interface{}
+	Err() error
+}
+
+//CopyStructs bulk loads input into table using the PostgreSQL copy protocol
+//The copied columns are the db tags of the struct not tagged readonly, table may be schema qualified
+//input is either a slice, a receive channel or a StructIterator of struct or pointer to struct
+func CopyStructs(ctx context.Context, conn copier, table string, input interface{}) (int64, error) {
+	var src *structSource
+	var err error
+	if it, ok := input.(StructIterator); ok {
+		src, err = newIteratorSource(it)
+	} else {
+		src, err = newReflectSource(ctx, input)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	//Empty iterator, nothing to copy
+	if src == nil {
+		return 0, nil
+	}
+
+	dbTagPos, err := mapping.DBTagPositions(src.rt)
+	if err != nil {
+		return 0, err
+	}
+
+	//Fields tagged readonly are left to the database
+	for _, column := range mapping.SortedDBTags(dbTagPos) {
+		if mapping.HasTagOption(src.rt.FieldByIndex(dbTagPos[column]), "readonly") {
+			continue
+		}
+		src.columns = append(src.columns, column)
+		src.positions = append(src.positions, dbTagPos[column])
+	}
+
+	return conn.CopyFrom(ctx, pgx.Identifier(strings.Split(table, ".")), src.columns, src)
+}
+
+//structSource is a pgx.CopyFromSource reading the tagged fields of a stream of structs
+type structSource struct {
+	rt        reflect.Type
+	next      func() (reflect.Value, bool, error)
+	columns   []string
+	positions [][]int
+	current   reflect.Value
+	err       error
+}
+
+func newReflectSource(ctx context.Context, input interface{}) (*structSource, error) {
+	rv := reflect.ValueOf(input)
+	if !rv.IsValid() {
+		return nil, fmt.Errorf("input value in invalid")
+	}
+
+	src := &structSource{}
+	switch rv.Kind() {
+	case reflect.Slice:
+		i := 0
+		src.next = func() (reflect.Value, bool, error) {
+			if i >= rv.Len() {
+				return reflect.Value{}, false, nil
+			}
+			i++
+			return rv.Index(i - 1), true, nil
+		}
+
+	case reflect.Chan:
+		if rv.Type().ChanDir()&reflect.RecvDir == 0 {
+			return nil, fmt.Errorf("input value is a send only channel")
+		}
+
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: rv},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+		}
+		src.next = func() (reflect.Value, bool, error) {
+			chosen, value, ok := reflect.Select(cases)
+			if chosen == 1 {
+				return reflect.Value{}, false, ctx.Err()
+			}
+			return value, ok, nil
+		}
+
+	default:
+		return nil, fmt.Errorf("input value is not a slice, channel or StructIterator")
+	}
+
+	src.rt = rv.Type().Elem()
+	if src.rt.Kind() == reflect.Ptr {
+		src.rt = src.rt.Elem()
+	}
+	if src.rt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("input value does not contain structs")
+	}
+
+	return src, nil
+}
+
+//newIteratorSource reads the first value of it to find the struct type being copied
+//It returns a nil source if the iterator is empty
+func newIteratorSource(it StructIterator) (*structSource, error) {
+	if !it.Next() {
+		return nil, it.Err()
+	}
+
+	first := reflect.ValueOf(it.Value())
+	if !first.IsValid() {
+		return nil, fmt.Errorf("iterator returned an invalid value")
+	}
+
+	src := &structSource{
+		rt: first.Type(),
+	}
+	if src.rt.Kind() == reflect.Ptr {
+		src.rt = src.rt.Elem()
+	}
+	if src.rt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("iterator value is not a struct or pointer to struct")
+	}
+
+	src.next = func() (reflect.Value, bool, error) {
+		if first.IsValid() {
+			value := first
+			first = reflect.Value{}
+			return value, true, nil
+		}
+		if !it.Next() {
+			return reflect.Value{}, false, it.Err()
+		}
+		return reflect.ValueOf(it.Value()), true, nil
+	}
+
+	return src, nil
+}
+
+func (src *structSource) Next() bool {
+	if src.err != nil {
+		return false
+	}
+
+	value, ok, err := src.next()
+	if err != nil {
+		src.err = err
+		return false
+	}
+	if !ok {
+		return false
+	}
+
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			src.err = fmt.Errorf("input contains a nil pointer")
+			return false
+		}
+		value = value.Elem()
+	}
+	if value.Type() != src.rt {
+		src.err = fmt.Errorf("input contains a %s, expected %s", value.Type().String(), src.rt.String())
+		return false
+	}
+
+	src.current = value
+	return true
+}
+
+func (src *structSource) Values() ([]interface{}, error) {
+	values := make([]interface{}, len(src.positions))
+	for i, fieldPos := range src.positions {
+		fieldVal, ok := mapping.FieldByIndex(src.current, fieldPos)
+		if !ok {
+			//A nil pointer on the path to the field is copied as NULL
+			continue
+		}
+
+		if !fieldVal.CanInterface() {
+			return nil, ErrUnexportedProperty{
+				PropertyName: src.rt.FieldByIndex(fieldPos).Name,
+			}
+		}
+		values[i] = fieldVal.Interface()
+	}
+
+	return values, nil
+}
+
+func (src *structSource) Err() error {
+	return src.err
+}
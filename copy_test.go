@@ -0,0 +1,85 @@
+package pgxscan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type copyTestStruct struct {
+	A string `db:"a"`
+	B *int   `db:"b"`
+	C string `db:"-"`
+}
+
+type copyTestIterator struct {
+	values []copyTestStruct
+	pos    int
+}
+
+func (it *copyTestIterator) Next() bool {
+	it.pos++
+	return it.pos <= len(it.values)
+}
+
+func (it *copyTestIterator) Value() interface{} {
+	return &it.values[it.pos-1]
+}
+
+func (it *copyTestIterator) Err() error {
+	return nil
+}
+
+func TestCopyStructs(t *testing.T) {
+	values := []copyTestStruct{
+		{A: "a", B: intPtr(1), C: "ignored"},
+		{A: "b", B: nil},
+	}
+
+	tests := map[string]struct {
+		input func() interface{}
+	}{
+		"Slice": {
+			input: func() interface{} {
+				return values
+			},
+		},
+		"Channel": {
+			input: func() interface{} {
+				ch := make(chan copyTestStruct, len(values))
+				for _, v := range values {
+					ch <- v
+				}
+				close(ch)
+				return ch
+			},
+		},
+		"Iterator": {
+			input: func() interface{} {
+				return &copyTestIterator{values: values}
+			},
+		},
+	}
+
+	ctx := context.Background()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := db.Exec(ctx, `CREATE TABLE copy_structs (a text, b int)`)
+			require.NoError(t, err)
+			defer db.Exec(ctx, `DROP TABLE copy_structs`)
+
+			count, err := CopyStructs(ctx, db, "public.copy_structs", tc.input())
+			require.NoError(t, err)
+			require.Equal(t, int64(len(values)), count)
+
+			var copied []copyTestStruct
+			err = Query(ctx, db, &copied, `SELECT a, b FROM copy_structs ORDER BY a`)
+			require.NoError(t, err)
+			require.Equal(t, []copyTestStruct{
+				{A: "a", B: intPtr(1)},
+				{A: "b", B: nil},
+			}, copied)
+		})
+	}
+}
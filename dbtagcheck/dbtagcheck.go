@@ -0,0 +1,170 @@
+//Package dbtagcheck defines an Analyzer reporting destinations passed to pgxscan that fail at runtime
+//
+//The destination of Query, Rows, QueryRow, NamedQuery, NamedQueryRow and the Batch queue methods
+//of pgxscan, pgxscan/pgxv5 and pgxscan/sqlscan is checked for
+//	- not being a pointer, or a pointer to the wrong kind of value
+//	- fields without a db tag, which should either be tagged or ignored with db:"-"
+//	- db tags used by more than one field
+//	- unexported tagged fields, which can't be scanned into
+//	- unknown or malformed tag options
+package dbtagcheck
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"github.com/Oliver-Fish/pgxscan"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const doc = `check db tags of structs scanned by pgxscan
+
+The dbtagcheck analyzer reports destinations passed to pgxscan that are not pointers,
+and structs with untagged fields, duplicate db tags, unexported tagged fields
+or invalid tag options.`
+
+//Analyzer reports destinations passed to pgxscan that fail at runtime
+var Analyzer = &analysis.Analyzer{
+	Name:     "dbtagcheck",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+var packages = map[string]bool{
+	"github.com/Oliver-Fish/pgxscan":         true,
+	"github.com/Oliver-Fish/pgxscan/pgxv5":   true,
+	"github.com/Oliver-Fish/pgxscan/sqlscan": true,
+}
+
+//destination is the position of the destination argument of a function and what it scans into
+type destination struct {
+	arg   int
+	slice bool
+}
+
+var functions = map[string]destination{
+	"Query":         {arg: 2, slice: true},
+	"Rows":          {arg: 1, slice: true},
+	"QueryRow":      {arg: 2},
+	"NamedQuery":    {arg: 2, slice: true},
+	"NamedQueryRow": {arg: 2},
+}
+
+var methods = map[string]destination{
+	"QueueRow":  {arg: 0},
+	"QueueRows": {arg: 0, slice: true},
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() == nil || !packages[fn.Pkg().Path()] {
+			return
+		}
+
+		dest, ok := functions[fn.Name()]
+		if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+			dest, ok = methods[fn.Name()]
+		}
+		if !ok || dest.arg >= len(call.Args) {
+			return
+		}
+
+		arg := call.Args[dest.arg]
+		checkDestination(pass, arg, pass.TypesInfo.TypeOf(arg), fn.Pkg().Name()+"."+fn.Name(), dest.slice)
+	})
+
+	return nil, nil
+}
+
+//checkDestination reports a destination of type t that isn't a pointer to a struct, or a pointer to a slice of struct
+func checkDestination(pass *analysis.Pass, arg ast.Expr, t types.Type, funcName string, slice bool) {
+	if t == nil {
+		return
+	}
+
+	//The dynamic type of interface values is only known at runtime
+	if types.IsInterface(t) {
+		return
+	}
+
+	expected := "a pointer to a struct"
+	if slice {
+		expected = "a pointer to a slice of struct"
+	}
+
+	ptr, ok := t.Underlying().(*types.Pointer)
+	if !ok {
+		pass.Reportf(arg.Pos(), "destination of %s is %s, expected %s", funcName, t, expected)
+		return
+	}
+
+	elem := ptr.Elem()
+	if slice {
+		s, ok := elem.Underlying().(*types.Slice)
+		if !ok {
+			pass.Reportf(arg.Pos(), "destination of %s is %s, expected %s", funcName, t, expected)
+			return
+		}
+		elem = s.Elem()
+	}
+
+	st, ok := elem.Underlying().(*types.Struct)
+	if !ok {
+		pass.Reportf(arg.Pos(), "destination of %s is %s, expected %s", funcName, t, expected)
+		return
+	}
+
+	checkStruct(pass, arg, st, elem.String(), nil, false, map[string]string{})
+}
+
+//checkStruct reports the tag problems of st, following the nested struct rules of pgxscan
+//readOnly is set when st is reached through an unexported field that isn't embedded, as reflect can't set its fields
+//fields holds the path of the field each tag has been seen on
+func checkStruct(pass *analysis.Pass, arg ast.Expr, st *types.Struct, structName string, path []string, readOnly bool, fields map[string]string) {
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		tag, options := pgxscan.ParseTag(reflect.StructTag(st.Tag(i)).Get("db"))
+		fieldPath := strings.Join(append(append([]string{}, path...), field.Name()), ".")
+
+		var nested *types.Struct
+		switch underlying := field.Type().Underlying().(type) {
+		case *types.Struct:
+			nested = underlying
+		case *types.Pointer:
+			nested, _ = underlying.Elem().Underlying().(*types.Struct)
+		}
+
+		switch {
+		case tag == "-":
+			continue
+		case tag != "":
+			if previous, ok := fields[tag]; ok {
+				pass.Reportf(arg.Pos(), "db tag %q of %s is also used by %s in %s", tag, fieldPath, previous, structName)
+			}
+			fields[tag] = fieldPath
+
+			if readOnly || !field.Exported() {
+				pass.Reportf(arg.Pos(), "db tag %q is on unexported field %s of %s", tag, fieldPath, structName)
+			}
+
+			if err := pgxscan.CheckTagOptions(options); err != nil {
+				pass.Reportf(arg.Pos(), "db tag of field %s of %s: %s", fieldPath, structName, err)
+			}
+		case nested != nil:
+			nestedReadOnly := readOnly || (!field.Exported() && !field.Embedded())
+			checkStruct(pass, arg, nested, structName, append(append([]string{}, path...), field.Name()), nestedReadOnly, fields)
+		default:
+			pass.Reportf(arg.Pos(), "field %s of %s has no db tag, tag it or ignore it with db:\"-\"", fieldPath, structName)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package dbtagcheck_test
+
+import (
+	"testing"
+
+	"github.com/Oliver-Fish/pgxscan/dbtagcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), dbtagcheck.Analyzer, "a")
+}
@@ -0,0 +1,14 @@
+//The analyzer is a module of its own so the pgxscan library does not depend on golang.org/x/tools
+module github.com/Oliver-Fish/pgxscan/dbtagcheck
+
+go 1.22.0
+
+require (
+	github.com/Oliver-Fish/pgxscan v0.1.0
+	golang.org/x/tools v0.30.0
+)
+
+require (
+	golang.org/x/mod v0.23.0 // indirect
+	golang.org/x/sync v0.11.0 // indirect
+)
@@ -0,0 +1,77 @@
+package a
+
+import (
+	"context"
+
+	"github.com/Oliver-Fish/pgxscan"
+)
+
+type valid struct {
+	A string `db:"a"`
+	B *int   `db:"b"`
+	C string `db:"-"`
+	Nested
+	Ptr *Pointed
+}
+
+type Pointed struct {
+	F string `db:"f"`
+}
+
+type Nested struct {
+	D string `db:"d"`
+}
+
+type untagged struct {
+	A string `db:"a"`
+	B string
+}
+
+type duplicate struct {
+	A string `db:"a"`
+	Nested
+	Other struct {
+		A string `db:"a"`
+	}
+}
+
+type unexported struct {
+	A string `db:"a"`
+	b string `db:"b"`
+	n Nested
+	nested
+}
+
+type options struct {
+	A string `db:"a,pk,readonly"`
+	B string `db:"b,unknown"`
+	C string `db:"c,readonly,writeonly"`
+}
+
+type nested struct {
+	E string `db:"e"`
+}
+
+func queries(ctx context.Context, tx pgxscan.Querier, input interface{}) {
+	var v valid
+	var vs []valid
+	pgxscan.QueryRow(ctx, tx, &v, ``)
+	pgxscan.Query(ctx, tx, &vs, ``)
+	pgxscan.Rows(nil, &vs)
+	pgxscan.QueryRow(ctx, tx, input, ``)
+
+	pgxscan.QueryRow(ctx, tx, v, ``)        // want `destination of pgxscan.QueryRow is a.valid, expected a pointer to a struct`
+	pgxscan.Query(ctx, tx, &v, ``)          // want `destination of pgxscan.Query is \*a.valid, expected a pointer to a slice of struct`
+	pgxscan.Rows(nil, vs)                   // want `destination of pgxscan.Rows is \[\]a.valid, expected a pointer to a slice of struct`
+	pgxscan.QueryRow(ctx, tx, new(int), ``) // want `destination of pgxscan.QueryRow is \*int, expected a pointer to a struct`
+
+	pgxscan.QueryRow(ctx, tx, &untagged{}, ``)   // want `field B of a.untagged has no db tag, tag it or ignore it with db:"-"`
+	pgxscan.QueryRow(ctx, tx, &duplicate{}, ``)  // want `db tag "a" of Other.A is also used by A in a.duplicate`
+	pgxscan.QueryRow(ctx, tx, &unexported{}, ``) // want `db tag "b" is on unexported field b of a.unexported` `db tag "d" is on unexported field n.D of a.unexported`
+
+	pgxscan.QueryRow(ctx, tx, &options{}, ``) // want `db tag of field B of a.options: unknown option "unknown"` `db tag of field C of a.options: options readonly and writeonly can not be combined`
+
+	var b pgxscan.Batch
+	b.QueueRow(&v, ``)
+	b.QueueRows(&v, ``) // want `destination of pgxscan.QueueRows is \*a.valid, expected a pointer to a slice of struct`
+}
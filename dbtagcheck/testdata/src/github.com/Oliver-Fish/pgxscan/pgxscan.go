@@ -0,0 +1,25 @@
+package pgxscan
+
+import "context"
+
+type Querier interface{}
+
+type rowSet interface{}
+
+func Query(ctx context.Context, tx Querier, input interface{}, query string, args ...interface{}) error {
+	return nil
+}
+
+func Rows(rows rowSet, input interface{}) error {
+	return nil
+}
+
+func QueryRow(ctx context.Context, tx Querier, input interface{}, query string, args ...interface{}) error {
+	return nil
+}
+
+type Batch struct{}
+
+func (b *Batch) QueueRow(input interface{}, query string, args ...interface{}) {}
+
+func (b *Batch) QueueRows(input interface{}, query string, args ...interface{}) {}
@@ -0,0 +1,50 @@
+package pgxscan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Oliver-Fish/pgxscan/internal/mapping"
+	"github.com/jackc/pgx/v4"
+)
+
+//CreateTableSQL returns a CREATE TABLE statement for table with a column for every db tag of input, a pointer to a struct
+//Column types follow the Go types of the fields, fields that can hold NULL such as pointers become nullable columns
+//and every other column is NOT NULL. Fields tagged pk make up the primary key and default=expression,
+//as in db:"created_at,default=now()", sets the column default to the SQL expression
+func CreateTableSQL(input interface{}, table string) (string, error) {
+	rt, err := destinationStruct(input)
+	if err != nil {
+		return "", err
+	}
+
+	dbTagPos, err := mapping.DBTagPositions(rt)
+	if err != nil {
+		return "", err
+	}
+
+	var definitions []string
+	for _, column := range mapping.SortedDBTags(dbTagPos) {
+		field := rt.FieldByIndex(dbTagPos[column])
+		pgType, ok := pgTypeForGo(field.Type)
+		if !ok {
+			return "", fmt.Errorf("no PostgreSQL type for field %s of type %s", field.Name, field.Type.String())
+		}
+
+		definition := pgx.Identifier{column}.Sanitize() + " " + pgType
+		if !nullableType(field.Type) {
+			definition += " NOT NULL"
+		}
+		if value, ok := mapping.TagOptionValue(field, "default"); ok {
+			definition += " DEFAULT " + value
+		}
+		definitions = append(definitions, definition)
+	}
+
+	pk := mapping.TagsWithOption(rt, dbTagPos, "pk")
+	if len(pk) > 0 {
+		definitions = append(definitions, "PRIMARY KEY ("+quoteColumns(pk, "")+")")
+	}
+
+	return "CREATE TABLE " + tableIdentifier(table) + " (\n\t" + strings.Join(definitions, ",\n\t") + "\n)", nil
+}
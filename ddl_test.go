@@ -0,0 +1,88 @@
+package pgxscan
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type ddlTestStruct struct {
+	OrgID     int            `db:"org_id,pk"`
+	ID        int64          `db:"id,pk"`
+	Name      string         `db:"name"`
+	Bio       *string        `db:"bio"`
+	Email     sql.NullString `db:"email"`
+	Tags      []string       `db:"tags"`
+	Active    bool           `db:"active,default=true"`
+	CreatedAt time.Time      `db:"created_at,readonly,default=now()"`
+	DeletedAt *time.Time     `db:"deleted_at,softdelete"`
+	Ignored   string         `db:"-"`
+}
+
+func TestCreateTableSQL(t *testing.T) {
+	type blob []byte
+
+	tests := map[string]struct {
+		input    interface{}
+		expected string
+		err      error
+	}{
+		"Struct": {
+			input: &ddlTestStruct{},
+			expected: `CREATE TABLE "public"."ddl" (
+	"org_id" bigint NOT NULL,
+	"id" bigint NOT NULL,
+	"name" text NOT NULL,
+	"bio" text,
+	"email" text,
+	"tags" text[],
+	"active" boolean NOT NULL DEFAULT true,
+	"created_at" timestamptz NOT NULL DEFAULT now(),
+	"deleted_at" timestamptz,
+	PRIMARY KEY ("org_id", "id")
+)`,
+		},
+		"Bytes": {
+			input: &struct {
+				Data json.RawMessage `db:"data"`
+				File blob            `db:"file"`
+			}{},
+			expected: `CREATE TABLE "public"."ddl" (
+	"data" jsonb,
+	"file" bytea
+)`,
+		},
+		"UnknownType": {
+			input: &struct {
+				A map[string]int `db:"a"`
+			}{},
+			err: fmt.Errorf("no PostgreSQL type for field A of type map[string]int"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			query, err := CreateTableSQL(tc.input, "public.ddl")
+			require.Equal(t, tc.err, err)
+			require.Equal(t, tc.expected, query)
+		})
+	}
+}
+
+func TestCreateTableSQLSchema(t *testing.T) {
+	query, err := CreateTableSQL(&ddlTestStruct{}, "ddl")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = db.Exec(ctx, query)
+	require.NoError(t, err)
+	defer db.Exec(ctx, `DROP TABLE ddl`)
+
+	err = CompareTable(ctx, db, "ddl", &ddlTestStruct{})
+	require.NoError(t, err)
+}
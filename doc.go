@@ -0,0 +1,20 @@
+//Package pgxscan scans pgx query results into structs and builds write statements from them
+//
+//Struct fields are mapped to columns by their db tag. A tag is the column name followed by comma separated
+//options, as in db:"id,pk" or db:"created_at,readonly,default=now()". Fields tagged db:"-" are ignored and
+//untagged struct fields, embedded or not, have their fields mapped as if they were declared in the outer struct
+//
+//The options are
+//
+//	pk           the column is part of the primary key used by GetByPK, DeleteByPK, ExistsByPK, Upsert and UpdateMany
+//	readonly     the column is scanned but never written, for columns set by the database
+//	writeonly    the column is written but never selected or scanned into
+//	omitempty    a zero value is not written, inserts use the column default and updates keep the stored value
+//	default=sql  the column default written by CreateTableSQL
+//	version      the integer column used for optimistic locking by Upsert and UpdateMany
+//	softdelete   the column holding the time a soft deleted row was deleted, NULL while it is not
+//	op=operator  the comparison of a filter field passed to Where, one of =, <>, <, <=, >, >=, like, ilike or in
+//
+//An option may be set once per tag and readonly can't be combined with writeonly. Mapping a struct with an
+//unknown or malformed option returns ErrInvalidTagOption
+package pgxscan
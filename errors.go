@@ -3,38 +3,187 @@ package pgxscan
 import (
 	"fmt"
 	"strings"
+
+	"github.com/Oliver-Fish/pgxscan/internal/mapping"
 )
 
 // ErrQueryColumnsTagsMismtach is returned when not all struct tags count does not match query column count
 // This is a non-fatal error and can be ignored if the above is by design or planned
 // This however acts as a fail-safe to avoid missing columns inside your select calls
-var ErrQueryColumnsTagsMismtach = fmt.Errorf("query returned less columns than DB tags on struct")
+var ErrQueryColumnsTagsMismtach = mapping.ErrQueryColumnsTagsMismtach
+
+type ErrUnexportedProperty = mapping.ErrUnexportedProperty
+
+type ErrNamedParameterMissing = mapping.ErrNamedParameterMissing
+
+type ErrQueryReturnedExtraColumns = mapping.ErrQueryReturnedExtraColumns
+
+//ErrInvalidTagOption is returned when the db tag of a field has an unknown or malformed option,
+//the options are listed in the [pgxscan] package documentation
+type ErrInvalidTagOption = mapping.ErrInvalidTagOption
+
+//GeneratedScanner is the reflection free mapping of a struct type emitted by pgxscan-gen
+type GeneratedScanner = mapping.GeneratedScanner
+
+//ErrBatch is returned by SendBatch when one or more queued items failed
+type ErrBatch struct {
+	Items []ErrBatchItem
+}
+
+func (err ErrBatch) Error() string {
+	itemOptionalPlural := "item"
+	if len(err.Items) > 1 {
+		itemOptionalPlural = "items"
+	}
+
+	messages := make([]string, len(err.Items))
+	for i, item := range err.Items {
+		messages[i] = item.Error()
+	}
+
+	return fmt.Sprintf("batch returned %d failed %s: %s", len(err.Items), itemOptionalPlural, strings.Join(messages, "; "))
+}
+
+//ErrBatchItem is the error of a single queued item, Index is its position in the batch
+type ErrBatchItem struct {
+	Index int
+	Query string
+	Err   error
+}
+
+func (err ErrBatchItem) Error() string {
+	return fmt.Sprintf("batch item %d: %s", err.Index, err.Err)
+}
+
+func (err ErrBatchItem) Unwrap() error {
+	return err.Err
+}
+
+//ErrMappingMismatch is returned by Check when a query's result columns do not match a struct's db tags
+//MissingColumns are db tags the query does not return, ExtraColumns are returned columns without a db tag
+type ErrMappingMismatch struct {
+	ValueType      string
+	MissingColumns []string
+	ExtraColumns   []string
+	TypeMismatches []TypeMismatch
+}
+
+func (err ErrMappingMismatch) Error() string {
+	var problems []string
+	if len(err.MissingColumns) > 0 {
+		problems = append(problems, fmt.Sprintf("missing columns %s", strings.Join(err.MissingColumns, ",")))
+	}
+	if len(err.ExtraColumns) > 0 {
+		problems = append(problems, fmt.Sprintf("extra columns %s", strings.Join(err.ExtraColumns, ",")))
+	}
+	for _, mismatch := range err.TypeMismatches {
+		problems = append(problems, mismatch.String())
+	}
+
+	return fmt.Sprintf("query does not match %s: %s", err.ValueType, strings.Join(problems, "; "))
+}
 
-type ErrUnexportedProperty struct {
-	PropertyName string
+//TypeMismatch is a column whose PostgreSQL type can not be scanned into the Go type of its field
+type TypeMismatch struct {
+	Column     string
+	ColumnType string
+	Field      string
+	FieldType  string
 }
 
-func (err ErrUnexportedProperty) Error() string {
-	return fmt.Sprintf("unable to access unexported field '%s'", err.PropertyName)
+func (m TypeMismatch) String() string {
+	return fmt.Sprintf("column %s of type %s can not be scanned into field %s of type %s", m.Column, m.ColumnType, m.Field, m.FieldType)
 }
 
-type ErrQueryReturnedExtraColumns struct {
+//ErrRegistry is returned by ValidateAll with every registered query that failed validation
+type ErrRegistry struct {
+	Queries []ErrRegisteredQuery
+}
+
+func (err ErrRegistry) Error() string {
+	queryOptionalPlural := "query"
+	if len(err.Queries) > 1 {
+		queryOptionalPlural = "queries"
+	}
+
+	messages := make([]string, len(err.Queries))
+	for i, query := range err.Queries {
+		messages[i] = query.Error()
+	}
+
+	return fmt.Sprintf("%d registered %s failed validation:\n%s", len(err.Queries), queryOptionalPlural, strings.Join(messages, "\n"))
+}
+
+//ErrRegisteredQuery is the validation error of a single registered query
+type ErrRegisteredQuery struct {
+	Name  string
+	Query string
+	Err   error
+}
+
+func (err ErrRegisteredQuery) Error() string {
+	return fmt.Sprintf("%s: %s", err.Name, err.Err)
+}
+
+func (err ErrRegisteredQuery) Unwrap() error {
+	return err.Err
+}
+
+//ErrTableMismatch is returned by CompareTable when a table's columns do not match a struct's db tags
+//MissingColumns are db tags the table does not have, ExtraColumns are table columns without a db tag
+//NullableColumns are nullable columns whose field can not hold NULL
+type ErrTableMismatch struct {
+	Table           string
+	ValueType       string
+	MissingColumns  []string
+	ExtraColumns    []string
+	NullableColumns []string
+	TypeMismatches  []TypeMismatch
+}
+
+func (err ErrTableMismatch) Error() string {
+	var problems []string
+	if len(err.MissingColumns) > 0 {
+		problems = append(problems, fmt.Sprintf("missing columns %s", strings.Join(err.MissingColumns, ",")))
+	}
+	if len(err.ExtraColumns) > 0 {
+		problems = append(problems, fmt.Sprintf("extra columns %s", strings.Join(err.ExtraColumns, ",")))
+	}
+	if len(err.NullableColumns) > 0 {
+		problems = append(problems, fmt.Sprintf("nullable columns %s scanned into non pointer fields", strings.Join(err.NullableColumns, ",")))
+	}
+	for _, mismatch := range err.TypeMismatches {
+		problems = append(problems, mismatch.String())
+	}
+
+	return fmt.Sprintf("table %s does not match %s: %s", err.Table, err.ValueType, strings.Join(problems, "; "))
+}
+
+//ErrPrimaryKey is returned by the primary key helpers when the keys passed do not match the pk tagged fields of a struct
+type ErrPrimaryKey struct {
 	ValueType string
 	Columns   []string
+	Keys      int
 }
 
-func (err ErrQueryReturnedExtraColumns) Error() string {
-	columnOptionalPlural := "column"
-	tagOptionalPlural := "tag"
-	if len(err.Columns) > 1 {
-		columnOptionalPlural = "columns"
-		tagOptionalPlural = "tags"
+func (err ErrPrimaryKey) Error() string {
+	if len(err.Columns) == 0 {
+		return fmt.Sprintf("%s has no fields tagged with the pk option", err.ValueType)
 	}
 
-	return fmt.Sprintf("query returned %s %s the supplied struct of type %T does not contain these %s",
-		columnOptionalPlural,
-		strings.Join(err.Columns, ","),
-		nil,
-		tagOptionalPlural,
-	)
+	return fmt.Sprintf("primary key %s of %s takes %d keys, %d were passed", strings.Join(err.Columns, ","), err.ValueType, len(err.Columns), err.Keys)
+}
+
+//ErrStaleVersion is returned by the write helpers when rows were not updated because their version field
+//no longer matches the database, Expected is the number of rows that should have been updated
+//ValueType is the pointer type of the written struct as in the other errors, whether or not a pointer was passed
+type ErrStaleVersion struct {
+	Table     string
+	ValueType string
+	Expected  int64
+	Updated   int64
+}
+
+func (err ErrStaleVersion) Error() string {
+	return fmt.Sprintf("%d of %d rows of %s in table %s have a stale version", err.Expected-err.Updated, err.Expected, err.ValueType, err.Table)
 }
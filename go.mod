@@ -1,10 +1,27 @@
 module github.com/Oliver-Fish/pgxscan
 
-go 1.16
+go 1.20
 
 require (
-	github.com/jackc/pgproto3/v2 v2.0.7 // indirect
+	github.com/jackc/pgconn v1.8.1
+	github.com/jackc/pgproto3/v2 v2.0.7
+	github.com/jackc/pgtype v1.7.0
 	github.com/jackc/pgx/v4 v4.11.0
-	github.com/stretchr/testify v1.7.0
-	golang.org/x/text v0.3.6 // indirect
+	github.com/jackc/pgx/v5 v5.6.0
+	github.com/stretchr/testify v1.8.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/jackc/chunkreader/v2 v2.0.1 // indirect
+	github.com/jackc/pgio v1.0.0 // indirect
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20221227161230-091c0ba34f0a // indirect
+	github.com/jackc/puddle v1.1.3 // indirect
+	github.com/jackc/puddle/v2 v2.2.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/crypto v0.17.0 // indirect
+	golang.org/x/sync v0.1.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
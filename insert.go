@@ -0,0 +1,117 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+//maxParameters is the most bind parameters PostgreSQL accepts in a single statement
+const maxParameters = 65535
+
+//InsertOptions changes the statements built by InsertMany
+type InsertOptions struct {
+	//OnConflict is appended to each statement after its VALUES, as in ON CONFLICT (id) DO NOTHING
+	OnConflict string
+
+	//Returning scans every db tag not tagged writeonly of the inserted rows back into values by position
+	//Rows skipped by OnConflict would misalign the values, so InsertMany fails if any are skipped
+	//and leaves the values of that statement untouched
+	Returning bool
+}
+
+//InsertMany inserts values into table with multi row INSERT statements and returns the number of rows inserted
+//The writable db tags of T, a struct, are inserted with zero values of fields tagged omitempty inserted as DEFAULT
+//Values are split over as many statements as needed to stay under the PostgreSQL bind parameter limit
+//The statements run one after another on q, pass a transaction for the insert to be all or nothing
+func InsertMany[T any](ctx context.Context, q Querier, table string, values []T, opts InsertOptions) (int64, error) {
+	rt := reflect.TypeOf((*T)(nil)).Elem()
+	if rt.Kind() != reflect.Struct {
+		return 0, fmt.Errorf("type %s is not a struct", rt.String())
+	}
+
+	ws, err := newWriteStruct(rt)
+	if err != nil {
+		return 0, err
+	}
+	if len(ws.writable) == 0 {
+		return 0, fmt.Errorf("type %s has no writable db tags", rt.String())
+	}
+
+	chunkSize := maxParameters / len(ws.writable)
+	rv := reflect.ValueOf(values)
+
+	var inserted int64
+	for start := 0; start < len(values); start += chunkSize {
+		end := start + chunkSize
+		if end > len(values) {
+			end = len(values)
+		}
+
+		query, args, err := insertQuery(ws, table, rv.Slice(start, end), opts)
+		if err != nil {
+			return inserted, err
+		}
+
+		if !opts.Returning {
+			count, err := exec(ctx, q, query, args...)
+			inserted += count
+			if err != nil {
+				return inserted, err
+			}
+			continue
+		}
+
+		rows, err := q.Query(ctx, query, args...)
+		if err != nil {
+			return inserted, err
+		}
+
+		//The rows are scanned into a copy of the chunk, keeping fields not returned such as writeonly ones,
+		//which is only copied back into values once every value is known to have its row
+		scanned := append([]T(nil), values[start:end]...)
+		err = Rows(rows, &scanned)
+		count := rows.CommandTag().RowsAffected()
+		inserted += count
+		if err != nil {
+			return inserted, err
+		}
+		if count != int64(end-start) {
+			return inserted, fmt.Errorf("insert returned %d rows for %d values, RETURNING can not be matched by position", count, end-start)
+		}
+		copy(values[start:end], scanned)
+	}
+
+	return inserted, nil
+}
+
+//insertQuery returns the INSERT statement and arguments of the structs in the slice chunk
+func insertQuery(ws *writeStruct, table string, chunk reflect.Value, opts InsertOptions) (string, []interface{}, error) {
+	args := make([]interface{}, 0, chunk.Len()*len(ws.writable))
+	rowValues := make([]string, chunk.Len())
+	for i := 0; i < chunk.Len(); i++ {
+		entries, values, err := ws.insertValues(chunk.Index(i), ws.writable, len(args)+1)
+		if err != nil {
+			return "", nil, err
+		}
+
+		rowValues[i] = "(" + strings.Join(entries, ", ") + ")"
+		args = append(args, values...)
+	}
+
+	var query strings.Builder
+	fmt.Fprintf(&query, "INSERT INTO %s (%s) VALUES %s",
+		tableIdentifier(table),
+		quoteColumns(ws.writable, ""),
+		strings.Join(rowValues, ", "),
+	)
+	if opts.OnConflict != "" {
+		fmt.Fprintf(&query, " %s", opts.OnConflict)
+	}
+	if opts.Returning {
+		fmt.Fprintf(&query, " RETURNING %s", quoteColumns(ws.readable, ""))
+	}
+
+	return query.String(), args, nil
+}
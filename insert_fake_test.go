@@ -0,0 +1,37 @@
+package pgxscan_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Oliver-Fish/pgxscan"
+	"github.com/Oliver-Fish/pgxscan/pgxscantest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertManySkippedReturning(t *testing.T) {
+	type testStruct struct {
+		ID   int    `db:"id,readonly"`
+		Name string `db:"name"`
+	}
+
+	//ON CONFLICT skipped the second value, only two rows are returned for three values
+	rows, err := pgxscantest.NewRows(
+		[]pgxscantest.Column{{Name: "id"}, {Name: "name"}},
+		[]interface{}{10, "a"},
+		[]interface{}{30, "c"},
+	)
+	require.NoError(t, err)
+
+	var q pgxscantest.Querier
+	q.Return(rows)
+
+	values := []testStruct{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+	inserted, err := pgxscan.InsertMany(context.Background(), &q, "t", values, pgxscan.InsertOptions{
+		OnConflict: "ON CONFLICT DO NOTHING",
+		Returning:  true,
+	})
+	require.EqualError(t, err, "insert returned 2 rows for 3 values, RETURNING can not be matched by position")
+	require.Equal(t, int64(2), inserted)
+	require.Equal(t, []testStruct{{Name: "a"}, {Name: "b"}, {Name: "c"}}, values)
+}
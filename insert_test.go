@@ -0,0 +1,60 @@
+package pgxscan
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type insertTestStruct struct {
+	ID   int    `db:"id,readonly"`
+	Name string `db:"name"`
+	B    *int   `db:"b"`
+}
+
+func TestInsertQuery(t *testing.T) {
+	ws, err := newWriteStruct(reflect.TypeOf(insertTestStruct{}))
+	require.NoError(t, err)
+
+	values := []insertTestStruct{
+		{Name: "a", B: intPtr(1)},
+		{Name: "b"},
+	}
+
+	query, args, err := insertQuery(ws, "insert_many", reflect.ValueOf(values), InsertOptions{
+		OnConflict: "ON CONFLICT DO NOTHING",
+		Returning:  true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, `INSERT INTO "insert_many" ("name", "b") VALUES ($1, $2), ($3, $4) ON CONFLICT DO NOTHING RETURNING "id", "name", "b"`, query)
+	require.Equal(t, []interface{}{"a", intPtr(1), "b", (*int)(nil)}, args)
+}
+
+func TestInsertMany(t *testing.T) {
+	ctx := context.Background()
+	_, err := db.Exec(ctx, `CREATE TABLE insert_many (id serial PRIMARY KEY, name text, b int)`)
+	require.NoError(t, err)
+	defer db.Exec(ctx, `DROP TABLE insert_many`)
+
+	//Enough rows for the insert to be split over two statements
+	values := make([]insertTestStruct, maxParameters/2+1)
+	for i := range values {
+		values[i].Name = "name"
+	}
+
+	count, err := InsertMany(ctx, db, "insert_many", values, InsertOptions{Returning: true})
+	require.NoError(t, err)
+	require.Equal(t, int64(len(values)), count)
+	for i, value := range values {
+		require.Equal(t, i+1, value.ID)
+	}
+
+	count, err = InsertMany(ctx, db, "insert_many", []insertTestStruct{{ID: 1, Name: "ignored"}}, InsertOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	_, err = InsertMany(ctx, db, "insert_many", []int{1}, InsertOptions{})
+	require.EqualError(t, err, "type int is not a struct")
+}
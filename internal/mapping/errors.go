@@ -0,0 +1,67 @@
+package mapping
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrQueryColumnsTagsMismtach is returned when not all struct tags count does not match query column count
+// This is a non-fatal error and can be ignored if the above is by design or planned
+// This however acts as a fail-safe to avoid missing columns inside your select calls
+var ErrQueryColumnsTagsMismtach = fmt.Errorf("query returned less columns than DB tags on struct")
+
+//ErrNoRows is returned when a single row scan finds no rows, each package replaces it with its driver's own error
+var ErrNoRows = fmt.Errorf("no rows in result set")
+
+type ErrUnexportedProperty struct {
+	PropertyName string
+}
+
+func (err ErrUnexportedProperty) Error() string {
+	return fmt.Sprintf("unable to access unexported field '%s'", err.PropertyName)
+}
+
+type ErrNamedParameterMissing struct {
+	Name string
+}
+
+func (err ErrNamedParameterMissing) Error() string {
+	return fmt.Sprintf("named parameter '%s' has no matching value", err.Name)
+}
+
+type ErrQueryReturnedExtraColumns struct {
+	ValueType string
+	Columns   []string
+}
+
+func (err ErrQueryReturnedExtraColumns) Error() string {
+	columnOptionalPlural := "column"
+	tagOptionalPlural := "tag"
+	if len(err.Columns) > 1 {
+		columnOptionalPlural = "columns"
+		tagOptionalPlural = "tags"
+	}
+
+	return fmt.Sprintf("query returned %s %s the supplied struct of type %T does not contain these %s",
+		columnOptionalPlural,
+		strings.Join(err.Columns, ","),
+		nil,
+		tagOptionalPlural,
+	)
+}
+
+//ErrInvalidTagOption is returned when the db tag of a field has an unknown or malformed option,
+//the options are listed in the [github.com/Oliver-Fish/pgxscan] package documentation
+type ErrInvalidTagOption struct {
+	Struct string
+	Field  string
+	Err    error
+}
+
+func (err ErrInvalidTagOption) Error() string {
+	return fmt.Sprintf("db tag of field %s of struct %s: %s", err.Field, err.Struct, err.Err)
+}
+
+func (err ErrInvalidTagOption) Unwrap() error {
+	return err.Err
+}
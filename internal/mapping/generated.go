@@ -0,0 +1,184 @@
+package mapping
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+//GeneratedScanner is the reflection free mapping of a struct type emitted by pgxscan-gen
+type GeneratedScanner struct {
+	//Tags is the number of db tags on the struct
+	Tags int
+
+	//Bind returns a function returning a pointer to the field of dest, a pointer to the struct, tagged column
+	//The function allocates nil pointers on the way to the field, found is false if the struct has no such tag
+	Bind func(column string) (field func(dest interface{}) interface{}, found bool, err error)
+
+	//New returns a pointer to a new struct
+	New func() interface{}
+
+	//Len, Index and Set work on a pointer to a slice of the struct
+	//Index returns a pointer to the element i and Set replaces the slice with the structs values point to
+	Len   func(slice interface{}) int
+	Index func(slice interface{}, i int) interface{}
+	Set   func(slice interface{}, values []interface{})
+}
+
+var generated sync.Map
+
+//RegisterScanner makes ScanRows and ScanRow use scanner for the struct type rt
+func RegisterScanner(rt reflect.Type, scanner GeneratedScanner) {
+	generated.Store(rt, &scanner)
+}
+
+//generatedScanner returns the scanner registered for the struct type of input
+//input is a pointer to the struct, or a pointer to a slice of it when slice is true
+func generatedScanner(input interface{}, slice bool) (*GeneratedScanner, bool) {
+	rt := reflect.TypeOf(input)
+	if rt == nil || rt.Kind() != reflect.Ptr {
+		return nil, false
+	}
+
+	rt = rt.Elem()
+	if slice {
+		if rt.Kind() != reflect.Slice {
+			return nil, false
+		}
+		rt = rt.Elem()
+	}
+
+	scanner, ok := generated.Load(rt)
+	if !ok {
+		return nil, false
+	}
+
+	return scanner.(*GeneratedScanner), true
+}
+
+//scanRows is ScanRows using a generated scanner
+func (s *GeneratedScanner) scanRows(rows Rows, input interface{}) error {
+	defer rows.Close()
+
+	if !rows.Next() {
+		return rows.Err()
+	}
+
+	headers := rows.Columns()
+	fields := make([]func(dest interface{}) interface{}, len(headers))
+	for i, header := range headers {
+		field, ok, err := s.Bind(header)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			//If the query returns a column the struct doesn't have this is a wasteful action so we fail
+			return fmt.Errorf("query returned column %s that is missing from passed struct", header)
+		}
+		fields[i] = field
+	}
+
+	//A slice that already has data is scanned into in place, as with the reflection path
+	sliceLen := s.Len(input)
+	var values []interface{}
+
+	fieldPtrs := make([]interface{}, len(headers))
+	for i := 0; ; i++ {
+		var dest interface{}
+		if sliceLen > 0 {
+			if i > sliceLen-1 {
+				return errors.New("query returned more rows that slice length")
+			}
+			dest = s.Index(input, i)
+		} else {
+			dest = s.New()
+			values = append(values, dest)
+		}
+
+		for ii, field := range fields {
+			fieldPtrs[ii] = field(dest)
+		}
+		err := rows.Scan(fieldPtrs...)
+		if err != nil {
+			return err
+		}
+
+		if !rows.Next() {
+			break
+		}
+	}
+
+	if sliceLen == 0 {
+		s.Set(input, values)
+	}
+
+	err := rows.Err()
+	if err != nil {
+		return err
+	}
+
+	if s.Tags != len(headers) {
+		return ErrQueryColumnsTagsMismtach
+	}
+
+	return nil
+}
+
+//scanRow is ScanRow using a generated scanner
+func (s *GeneratedScanner) scanRow(rows Rows, input interface{}) error {
+	defer rows.Close()
+
+	if !rows.Next() {
+		err := rows.Err()
+		if err != nil {
+			return err
+		}
+
+		return ErrNoRows
+	}
+
+	headers := rows.Columns()
+
+	var extracolumnsError *ErrQueryReturnedExtraColumns
+	var rejectedValues interface{}
+	fieldPtrs := make([]interface{}, len(headers))
+	for ii, header := range headers {
+		field, ok, err := s.Bind(header)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			//Extra columns are reported once the row is scanned, as with the reflection path
+			if extracolumnsError == nil {
+				extracolumnsError = &ErrQueryReturnedExtraColumns{
+					ValueType: fmt.Sprintf("%T", input),
+				}
+			}
+			extracolumnsError.Columns = append(extracolumnsError.Columns, header)
+
+			fieldPtrs[ii] = &rejectedValues
+			continue
+		}
+		fieldPtrs[ii] = field(input)
+	}
+
+	err := rows.Scan(fieldPtrs...)
+	if err != nil {
+		return err
+	}
+
+	if rows.Next() {
+		return errors.New("query returned more than one row")
+	}
+
+	if extracolumnsError != nil {
+		return extracolumnsError
+	}
+
+	if s.Tags != len(headers) {
+		return ErrQueryColumnsTagsMismtach
+	}
+
+	return nil
+}
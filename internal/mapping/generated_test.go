@@ -0,0 +1,173 @@
+package mapping
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type generatedTestStruct struct {
+	A string `db:"a"`
+	B int    `db:"b"`
+}
+
+//testRows is a Rows returning fixed values, each value is set directly on its destination
+type testRows struct {
+	columns []string
+	values  [][]interface{}
+	pos     int
+}
+
+func (r *testRows) Next() bool {
+	r.pos++
+	return r.pos <= len(r.values)
+}
+
+func (r *testRows) Scan(dest ...interface{}) error {
+	for i, d := range dest {
+		reflect.ValueOf(d).Elem().Set(reflect.ValueOf(r.values[r.pos-1][i]))
+	}
+	return nil
+}
+
+func (r *testRows) Err() error {
+	return nil
+}
+
+func (r *testRows) Close() {}
+
+func (r *testRows) Columns() []string {
+	return r.columns
+}
+
+func init() {
+	RegisterScanner(reflect.TypeOf(generatedTestStruct{}), GeneratedScanner{
+		Tags: 2,
+		Bind: func(column string) (func(dest interface{}) interface{}, bool, error) {
+			switch column {
+			case "a":
+				return func(dest interface{}) interface{} {
+					return &dest.(*generatedTestStruct).A
+				}, true, nil
+			case "b":
+				return func(dest interface{}) interface{} {
+					return &dest.(*generatedTestStruct).B
+				}, true, nil
+			}
+			return nil, false, nil
+		},
+		New: func() interface{} {
+			return new(generatedTestStruct)
+		},
+		Len: func(slice interface{}) int {
+			return len(*slice.(*[]generatedTestStruct))
+		},
+		Index: func(slice interface{}, i int) interface{} {
+			return &(*slice.(*[]generatedTestStruct))[i]
+		},
+		Set: func(slice interface{}, values []interface{}) {
+			s := make([]generatedTestStruct, len(values))
+			for i, v := range values {
+				s[i] = *v.(*generatedTestStruct)
+			}
+			*slice.(*[]generatedTestStruct) = s
+		},
+	})
+}
+
+func TestGeneratedScanRows(t *testing.T) {
+	tests := map[string]struct {
+		rows     *testRows
+		existing []generatedTestStruct
+		expected []generatedTestStruct
+		err      error
+	}{
+		"NewSlice": {
+			rows: &testRows{
+				columns: []string{"a", "b"},
+				values:  [][]interface{}{{"x", 1}, {"y", 2}},
+			},
+			expected: []generatedTestStruct{{A: "x", B: 1}, {A: "y", B: 2}},
+		},
+		"ExistingSlice": {
+			rows: &testRows{
+				columns: []string{"b"},
+				values:  [][]interface{}{{3}},
+			},
+			existing: []generatedTestStruct{{A: "kept"}},
+			expected: []generatedTestStruct{{A: "kept", B: 3}},
+			err:      ErrQueryColumnsTagsMismtach,
+		},
+		"ExtraColumn": {
+			rows: &testRows{
+				columns: []string{"a", "c"},
+				values:  [][]interface{}{{"x", "z"}},
+			},
+			err: fmt.Errorf("query returned column c that is missing from passed struct"),
+		},
+		"NoRows": {
+			rows: &testRows{
+				columns: []string{"a", "b"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			values := tc.existing
+			err := ScanRows(tc.rows, &values)
+			require.Equal(t, tc.err, err)
+			if err == nil || err == ErrQueryColumnsTagsMismtach {
+				require.Equal(t, tc.expected, values)
+			}
+		})
+	}
+}
+
+func TestGeneratedScanRow(t *testing.T) {
+	tests := map[string]struct {
+		rows     *testRows
+		expected generatedTestStruct
+		err      error
+	}{
+		"Row": {
+			rows: &testRows{
+				columns: []string{"a", "b"},
+				values:  [][]interface{}{{"x", 1}},
+			},
+			expected: generatedTestStruct{A: "x", B: 1},
+		},
+		"ExtraColumn": {
+			rows: &testRows{
+				columns: []string{"a", "b", "c"},
+				values:  [][]interface{}{{"x", 1, "z"}},
+			},
+			expected: generatedTestStruct{A: "x", B: 1},
+			err: &ErrQueryReturnedExtraColumns{
+				ValueType: "*mapping.generatedTestStruct",
+				Columns:   []string{"c"},
+			},
+		},
+		"NoRows": {
+			rows: &testRows{
+				columns: []string{"a", "b"},
+			},
+			err: ErrNoRows,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var value generatedTestStruct
+			rv, dbTagPos, err := ValidateRowInput(&value)
+			require.NoError(t, err)
+			require.Nil(t, dbTagPos)
+
+			err = ScanRow(tc.rows, &value, rv, dbTagPos)
+			require.Equal(t, tc.err, err)
+			require.Equal(t, tc.expected, value)
+		})
+	}
+}
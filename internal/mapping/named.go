@@ -0,0 +1,236 @@
+package mapping
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+//Named rewrites the :name parameters in query to $n placeholders and returns the ordered args for them
+//arg must be a struct, a pointer to a struct or a map with string keys
+//Quoted strings, quoted identifiers, comments, :: casts, array slices and dollar quoted bodies are left untouched
+func Named(query string, arg interface{}) (string, []interface{}, error) {
+	lookup, err := namedArgLookup(arg)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var out strings.Builder
+	out.Grow(len(query))
+
+	var args []interface{}
+	//A name used more than once is bound to the same placeholder
+	positions := make(map[string]int)
+
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"':
+			end := skipQuoted(query, i)
+			out.WriteString(query[i:end])
+			i = end
+
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end == -1 {
+				end = len(query) - i
+			}
+			out.WriteString(query[i : i+end])
+			i += end
+
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := skipBlockComment(query, i)
+			out.WriteString(query[i:end])
+			i = end
+
+		case c == '$' && i > 0 && isNamePart(query[i-1]):
+			//Dollar signs are valid inside identifiers
+			out.WriteByte(c)
+			i++
+
+		case c == '$':
+			if i+1 < len(query) && query[i+1] >= '0' && query[i+1] <= '9' {
+				return "", nil, fmt.Errorf("query mixes positional parameters with named parameters")
+			}
+			end := skipDollarQuoted(query, i)
+			out.WriteString(query[i:end])
+			i = end
+
+		case c == ':' && strings.HasPrefix(query[i:], "::"):
+			//Type cast, never a parameter
+			out.WriteString("::")
+			i += 2
+
+		case c == ':' && i > 0 && (isNamePart(query[i-1]) || query[i-1] == ']'):
+			//Array slice bounds, as in arr[1:n], never a parameter
+			out.WriteByte(c)
+			i++
+
+		case c == ':' && i+1 < len(query) && isNameStart(query[i+1]):
+			end := i + 1
+			for end < len(query) && isNamePart(query[end]) {
+				end++
+			}
+			name := query[i+1 : end]
+
+			pos, ok := positions[name]
+			if !ok {
+				value, found, err := lookup(name)
+				if err != nil {
+					return "", nil, err
+				}
+				if !found {
+					return "", nil, ErrNamedParameterMissing{Name: name}
+				}
+				args = append(args, value)
+				pos = len(args)
+				positions[name] = pos
+			}
+
+			out.WriteByte('$')
+			out.WriteString(strconv.Itoa(pos))
+			i = end
+
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+
+	return out.String(), args, nil
+}
+
+//namedArgLookup returns a function resolving a parameter name to its value in arg
+func namedArgLookup(arg interface{}) (func(name string) (interface{}, bool, error), error) {
+	rv := reflect.ValueOf(arg)
+	if !rv.IsValid() {
+		return nil, fmt.Errorf("named argument is invalid")
+	}
+
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("named argument is a nil pointer")
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("named argument map keys are not strings")
+		}
+
+		return func(name string) (interface{}, bool, error) {
+			value := rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key()))
+			if !value.IsValid() {
+				return nil, false, nil
+			}
+			return value.Interface(), true, nil
+		}, nil
+
+	case reflect.Struct:
+		dbTagPos, err := DBTagPositions(rv.Type())
+		if err != nil {
+			return nil, err
+		}
+
+		return func(name string) (interface{}, bool, error) {
+			fieldPos, ok := dbTagPos[name]
+			if !ok {
+				return nil, false, nil
+			}
+
+			fieldVal, ok := FieldByIndex(rv, fieldPos)
+			if !ok {
+				//A nil pointer on the path to the field binds as NULL
+				return nil, true, nil
+			}
+
+			if !fieldVal.CanInterface() {
+				return nil, false, ErrUnexportedProperty{
+					PropertyName: rv.Type().FieldByIndex(fieldPos).Name,
+				}
+			}
+
+			return fieldVal.Interface(), true, nil
+		}, nil
+	}
+
+	return nil, fmt.Errorf("named argument is not a struct or a map")
+}
+
+//skipQuoted returns the index after the quoted string or identifier starting at start
+//Doubled quotes are treated as escaped, as are backslash escapes inside E'' strings
+func skipQuoted(query string, start int) int {
+	quote := query[start]
+	backslashEscapes := quote == '\'' && start > 0 && (query[start-1] == 'E' || query[start-1] == 'e')
+
+	for i := start + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			if backslashEscapes {
+				i++
+			}
+		case quote:
+			if i+1 < len(query) && query[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+
+	return len(query)
+}
+
+//skipBlockComment returns the index after the (possibly nested) block comment starting at start
+func skipBlockComment(query string, start int) int {
+	depth := 0
+	for i := start; i < len(query)-1; i++ {
+		switch {
+		case query[i] == '/' && query[i+1] == '*':
+			depth++
+			i++
+		case query[i] == '*' && query[i+1] == '/':
+			depth--
+			i++
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+
+	return len(query)
+}
+
+//skipDollarQuoted returns the index after the dollar quoted body starting at start
+//If start is not the opening of a dollar quote only the dollar sign is skipped
+func skipDollarQuoted(query string, start int) int {
+	end := start + 1
+	for end < len(query) && query[end] != '$' {
+		if !isNamePart(query[end]) {
+			return start + 1
+		}
+		end++
+	}
+	if end >= len(query) {
+		return start + 1
+	}
+
+	delimiter := query[start : end+1]
+	closing := strings.Index(query[end+1:], delimiter)
+	if closing == -1 {
+		return len(query)
+	}
+
+	return end + 1 + closing + len(delimiter)
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNamePart(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
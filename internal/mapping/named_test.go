@@ -0,0 +1,95 @@
+package mapping
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestNamed(t *testing.T) {
+	type Tenant struct {
+		TenantID int `db:"tenant_id"`
+	}
+	type testStruct struct {
+		ID   int     `db:"id"`
+		Name *string `db:"name"`
+		*Tenant
+		Ignored string `db:"-"`
+	}
+
+	tests := map[string]struct {
+		query         string
+		arg           interface{}
+		expectedQuery string
+		expectedArgs  []interface{}
+		expectedError error
+	}{
+		"Struct": {
+			query:         `SELECT * FROM t WHERE id = :id AND tenant = :tenant_id`,
+			arg:           testStruct{ID: 1, Tenant: &Tenant{TenantID: 2}},
+			expectedQuery: `SELECT * FROM t WHERE id = $1 AND tenant = $2`,
+			expectedArgs:  []interface{}{1, 2},
+		},
+		"Pointer to struct": {
+			query:         `SELECT * FROM t WHERE name = :name`,
+			arg:           &testStruct{Name: stringPtr("a")},
+			expectedQuery: `SELECT * FROM t WHERE name = $1`,
+			expectedArgs:  []interface{}{stringPtr("a")},
+		},
+		"Nil embedded pointer binds NULL": {
+			query:         `SELECT * FROM t WHERE tenant = :tenant_id`,
+			arg:           testStruct{},
+			expectedQuery: `SELECT * FROM t WHERE tenant = $1`,
+			expectedArgs:  []interface{}{nil},
+		},
+		"Map": {
+			query:         `SELECT * FROM t WHERE id = :id AND tenant = :tenant_id`,
+			arg:           map[string]interface{}{"id": 1, "tenant_id": "x"},
+			expectedQuery: `SELECT * FROM t WHERE id = $1 AND tenant = $2`,
+			expectedArgs:  []interface{}{1, "x"},
+		},
+		"Repeated name": {
+			query:         `SELECT * FROM t WHERE a = :id OR b = :id`,
+			arg:           map[string]interface{}{"id": 1},
+			expectedQuery: `SELECT * FROM t WHERE a = $1 OR b = $1`,
+			expectedArgs:  []interface{}{1},
+		},
+		"Untouched syntax": {
+			query: `SELECT ':id', E'\':id', ":id", x::text, $f$ :id $f$, $$ :id $$ -- :id
+			/* :id /* :id */ :id */ FROM t WHERE id = :id`,
+			arg: map[string]interface{}{"id": 1},
+			expectedQuery: `SELECT ':id', E'\':id', ":id", x::text, $f$ :id $f$, $$ :id $$ -- :id
+			/* :id /* :id */ :id */ FROM t WHERE id = $1`,
+			expectedArgs: []interface{}{1},
+		},
+		"Array slice": {
+			query:         `SELECT arr[1:n], arr[i:n], arr[1][2:n], arr[:id] FROM t WHERE id = :id`,
+			arg:           map[string]interface{}{"id": 1},
+			expectedQuery: `SELECT arr[1:n], arr[i:n], arr[1][2:n], arr[$1] FROM t WHERE id = $1`,
+			expectedArgs:  []interface{}{1},
+		},
+		"Missing parameter": {
+			query:         `SELECT * FROM t WHERE id = :missing`,
+			arg:           map[string]interface{}{"id": 1},
+			expectedError: ErrNamedParameterMissing{Name: "missing"},
+		},
+		"Ignored field is not a parameter": {
+			query:         `SELECT * FROM t WHERE id = :ignored`,
+			arg:           testStruct{},
+			expectedError: ErrNamedParameterMissing{Name: "ignored"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			query, args, err := Named(tc.query, tc.arg)
+			require.Equal(t, tc.expectedError, err)
+			require.Equal(t, tc.expectedQuery, query)
+			require.Equal(t, tc.expectedArgs, args)
+		})
+	}
+}
@@ -0,0 +1,277 @@
+//Package mapping holds the driver independent struct mapping shared by the pgx v4 and v5 packages
+package mapping
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+//ParseTag splits a db tag into the column name and the options following it, as in db:"id,pk"
+//The grammar is name,option,option=value where values run to the next comma
+func ParseTag(tag string) (string, []string) {
+	parts := strings.Split(tag, ",")
+	return parts[0], parts[1:]
+}
+
+//tagOptions are the options a db tag may have after the column name, true when the option takes a value
+//They are documented in the pgxscan package documentation
+var tagOptions = map[string]bool{
+	"pk":         false,
+	"readonly":   false,
+	"writeonly":  false,
+	"omitempty":  false,
+	"default":    true,
+	"version":    false,
+	"softdelete": false,
+	"op":         true,
+}
+
+//whereOperators are the values of the op option, mapped to the SQL operator Where writes for them
+var whereOperators = map[string]string{
+	"=":     "=",
+	"<>":    "<>",
+	"<":     "<",
+	"<=":    "<=",
+	">":     ">",
+	">=":    ">=",
+	"like":  "LIKE",
+	"ilike": "ILIKE",
+	"in":    "IN",
+}
+
+//WhereOperator returns the SQL operator of op, the value of an op option, which is case insensitive
+func WhereOperator(op string) (string, bool) {
+	operator, ok := whereOperators[strings.ToLower(op)]
+	return operator, ok
+}
+
+//CheckTagOptions returns an error describing the first invalid option of a db tag
+func CheckTagOptions(options []string) error {
+	seen := make(map[string]bool, len(options))
+	for _, option := range options {
+		name, value, hasValue := strings.Cut(option, "=")
+		takesValue, ok := tagOptions[name]
+		if !ok {
+			return fmt.Errorf("unknown option %q", option)
+		}
+		if takesValue != hasValue {
+			if takesValue {
+				return fmt.Errorf("option %q needs a value, as in %s=value", name, name)
+			}
+			return fmt.Errorf("option %q does not take a value", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("option %q is set more than once", name)
+		}
+		if _, ok := WhereOperator(value); name == "op" && !ok {
+			return fmt.Errorf("option %q has unknown operator %q", name, value)
+		}
+		seen[name] = true
+	}
+
+	if seen["readonly"] && seen["writeonly"] {
+		return fmt.Errorf("options readonly and writeonly can not be combined")
+	}
+
+	return nil
+}
+
+//checkField returns an ErrInvalidTagOption if the db tag of field, a field of rt, has an invalid option
+func checkField(rt reflect.Type, field reflect.StructField, options []string) error {
+	err := CheckTagOptions(options)
+	if err != nil {
+		return ErrInvalidTagOption{
+			Struct: rt.String(),
+			Field:  field.Name,
+			Err:    err,
+		}
+	}
+
+	return nil
+}
+
+//HasTagOption reports whether the db tag of field has option
+func HasTagOption(field reflect.StructField, option string) bool {
+	_, options := ParseTag(field.Tag.Get("db"))
+	for _, o := range options {
+		if o == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+//TagOptionValue returns the value of an option of the db tag of field written as option=value
+func TagOptionValue(field reflect.StructField, option string) (string, bool) {
+	_, options := ParseTag(field.Tag.Get("db"))
+	for _, o := range options {
+		if strings.HasPrefix(o, option+"=") {
+			return strings.TrimPrefix(o, option+"="), true
+		}
+	}
+
+	return "", false
+}
+
+//TagsWithOption returns the tags of dbTagPos in field order whose field in the struct type rt has option
+func TagsWithOption(rt reflect.Type, dbTagPos map[string][]int, option string) []string {
+	var tags []string
+	for _, tag := range SortedDBTags(dbTagPos) {
+		if HasTagOption(rt.FieldByIndex(dbTagPos[tag]), option) {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+//DBTagPositions returns the index path of every db tagged field of the struct type rt keyed by tag
+func DBTagPositions(rt reflect.Type) (map[string][]int, error) {
+	if rt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("reflect type is not a struct")
+	}
+
+	tagPositions := make(map[string][]int)
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+
+		switch field.Type.Kind() {
+		case reflect.Struct:
+			tag, options := ParseTag(field.Tag.Get("db"))
+			if tag == "-" {
+				//If an embeded struct has a ignore db tag
+				//skip entire struct lookup, in this case we shouldn't have a tag
+				continue
+			}
+			if tag != "" {
+				if err := checkField(rt, field, options); err != nil {
+					return nil, err
+				}
+
+				//Tag Found so add it to the list and don't go deeper
+				tagPositions[tag] = field.Index
+				continue
+			}
+
+			//Get all tags on nested struct
+			nestedTags, err := DBTagPositions(field.Type)
+			if err != nil {
+				return nil, err
+			}
+
+			//Add all nested positions to top level map
+			for t, ni := range nestedTags {
+				tagPositions[t] = append([]int{i}, ni...)
+			}
+
+		case reflect.Ptr:
+			tag, options := ParseTag(field.Tag.Get("db"))
+			if tag == "-" {
+				//If an embeded struct has a ignore db tag
+				//skip entire struct lookup, in this case we shouldn't have a tag
+				continue
+			}
+			if tag != "" {
+				if err := checkField(rt, field, options); err != nil {
+					return nil, err
+				}
+
+				//Tag Found so add it to the list and don't go deeper
+				tagPositions[tag] = field.Index
+				continue
+			}
+			underlineType := field.Type.Elem()
+			if underlineType.Kind() == reflect.Struct {
+				//Get all tags on nested struct
+				nestedTags, err := DBTagPositions(underlineType)
+				if err != nil {
+					return nil, err
+				}
+
+				//Add all nested positions to top level map
+				for t, ni := range nestedTags {
+					tagPositions[t] = append([]int{i}, ni...)
+				}
+				continue
+			}
+			//If we have a pointer that doesn't point to a struct then we don't need to look deeper
+			fallthrough
+		default:
+			tag, options := ParseTag(field.Tag.Get("db"))
+			//If we find a case where no tag is set return error
+			//tags should either be set or have a dash to be ignored
+			if tag == "" {
+				return nil, fmt.Errorf("unset tag on property %d of struct %s", i, rt.String())
+			}
+
+			if tag == "-" {
+				continue
+			}
+
+			if err := checkField(rt, field, options); err != nil {
+				return nil, err
+			}
+
+			tagPositions[tag] = field.Index
+
+		}
+	}
+
+	return tagPositions, nil
+}
+
+//ReadTagPositions is DBTagPositions without the fields tagged writeonly, which are never scanned into
+func ReadTagPositions(rt reflect.Type) (map[string][]int, error) {
+	dbTagPos, err := DBTagPositions(rt)
+	if err != nil {
+		return nil, err
+	}
+
+	for tag, fieldPos := range dbTagPos {
+		if HasTagOption(rt.FieldByIndex(fieldPos), "writeonly") {
+			delete(dbTagPos, tag)
+		}
+	}
+
+	return dbTagPos, nil
+}
+
+//SortedDBTags returns the tags of dbTagPos in the order their fields are declared
+func SortedDBTags(dbTagPos map[string][]int) []string {
+	tags := make([]string, 0, len(dbTagPos))
+	for tag := range dbTagPos {
+		tags = append(tags, tag)
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		a, b := dbTagPos[tags[i]], dbTagPos[tags[j]]
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+
+	return tags
+}
+
+//FieldByIndex is reflect.Value.FieldByIndex without allocating or panicking on nil embedded pointers
+//It returns false if a nil pointer is found on the path to the field
+func FieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, pos := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(pos)
+	}
+
+	return v, true
+}
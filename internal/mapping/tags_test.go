@@ -0,0 +1,96 @@
+package mapping
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBTagPositionsOptions(t *testing.T) {
+	tests := map[string]struct {
+		input    interface{}
+		expected map[string][]int
+		read     map[string][]int
+		err      error
+	}{
+		"Options": {
+			input: struct {
+				ID        int    `db:"id,pk,readonly"`
+				Password  string `db:"password,writeonly"`
+				Name      string `db:"name,omitempty,default='x'"`
+				Version   int    `db:"version,version"`
+				DeletedAt *int   `db:"deleted_at,softdelete"`
+				Rank      *int   `db:"rank,op=ILIKE"`
+			}{},
+			expected: map[string][]int{"id": {0}, "password": {1}, "name": {2}, "version": {3}, "deleted_at": {4}, "rank": {5}},
+			read:     map[string][]int{"id": {0}, "name": {2}, "version": {3}, "deleted_at": {4}, "rank": {5}},
+		},
+		"UnknownOperator": {
+			input: struct {
+				A *int `db:"a,op=~"`
+			}{},
+			err: ErrInvalidTagOption{
+				Struct: "struct { A *int \"db:\\\"a,op=~\\\"\" }",
+				Field:  "A",
+				Err:    fmt.Errorf("option %q has unknown operator %q", "op", "~"),
+			},
+		},
+		"UnknownOption": {
+			input: struct {
+				ID int `db:"id,primary"`
+			}{},
+			err: ErrInvalidTagOption{
+				Struct: "struct { ID int \"db:\\\"id,primary\\\"\" }",
+				Field:  "ID",
+				Err:    fmt.Errorf("unknown option %q", "primary"),
+			},
+		},
+		"MissingValue": {
+			input: struct {
+				ID int `db:"id,default"`
+			}{},
+			err: ErrInvalidTagOption{
+				Struct: "struct { ID int \"db:\\\"id,default\\\"\" }",
+				Field:  "ID",
+				Err:    fmt.Errorf("option %q needs a value, as in default=value", "default"),
+			},
+		},
+		"UnexpectedValue": {
+			input: struct {
+				ID int `db:"id,pk=true"`
+			}{},
+			err: ErrInvalidTagOption{
+				Struct: "struct { ID int \"db:\\\"id,pk=true\\\"\" }",
+				Field:  "ID",
+				Err:    fmt.Errorf("option %q does not take a value", "pk"),
+			},
+		},
+		"ReadOnlyWriteOnly": {
+			input: struct {
+				Nested struct {
+					ID int `db:"id,readonly,writeonly"`
+				}
+			}{},
+			err: ErrInvalidTagOption{
+				Struct: "struct { ID int \"db:\\\"id,readonly,writeonly\\\"\" }",
+				Field:  "ID",
+				Err:    fmt.Errorf("options readonly and writeonly can not be combined"),
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rt := reflect.TypeOf(tc.input)
+			dbTagPos, err := DBTagPositions(rt)
+			require.Equal(t, tc.err, err)
+			require.Equal(t, tc.expected, dbTagPos)
+
+			read, err := ReadTagPositions(rt)
+			require.Equal(t, tc.err, err)
+			require.Equal(t, tc.read, read)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package pgxscan
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+//Middleware decorates a Querier, allowing logging, tracing, timeouts or retries to wrap every query
+type Middleware func(Querier) Querier
+
+//Chain wraps q in every middleware, the first middleware is the outermost and sees each call first
+func Chain(q Querier, middleware ...Middleware) Querier {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		q = middleware[i](q)
+	}
+
+	return q
+}
+
+//QueryFunc is the signature of Querier.Query
+type QueryFunc func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+
+//QueryRowFunc is the signature of Querier.QueryRow
+type QueryRowFunc func(ctx context.Context, sql string, args ...interface{}) pgx.Row
+
+//QuerierFuncs is a Querier built from functions, easing writing middleware that only wraps some calls
+//A nil function falls through to Next
+type QuerierFuncs struct {
+	Next         Querier
+	QueryFunc    QueryFunc
+	QueryRowFunc QueryRowFunc
+}
+
+func (q QuerierFuncs) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	if q.QueryFunc == nil {
+		return q.Next.Query(ctx, sql, args...)
+	}
+
+	return q.QueryFunc(ctx, sql, args...)
+}
+
+func (q QuerierFuncs) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	if q.QueryRowFunc == nil {
+		return q.Next.QueryRow(ctx, sql, args...)
+	}
+
+	return q.QueryRowFunc(ctx, sql, args...)
+}
+
+//Conn returns conn as a Querier
+func Conn(conn *pgx.Conn) Querier {
+	return conn
+}
+
+//Pool returns pool as a Querier
+func Pool(pool *pgxpool.Pool) Querier {
+	return pool
+}
+
+//Tx returns tx as a Querier
+func Tx(tx pgx.Tx) Querier {
+	return tx
+}
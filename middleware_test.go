@@ -0,0 +1,67 @@
+package pgxscan
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next Querier) Querier {
+			return QuerierFuncs{
+				Next: next,
+				QueryFunc: func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+					calls = append(calls, name)
+					return next.Query(ctx, sql, args...)
+				},
+			}
+		}
+	}
+
+	errQuery := errors.New("query failed")
+	base := QuerierFuncs{
+		QueryFunc: func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+			calls = append(calls, "base")
+			return nil, errQuery
+		},
+	}
+
+	type testStruct struct {
+		A string `db:"a"`
+	}
+
+	var val testStruct
+	ctx := context.Background()
+	err := QueryRow(ctx, Chain(base, record("first"), record("second")), &val, `SELECT 'a' as a`)
+	require.Equal(t, errQuery, err)
+	require.Equal(t, []string{"first", "second", "base"}, calls)
+}
+
+func TestChainPool(t *testing.T) {
+	var queries []string
+	logging := func(next Querier) Querier {
+		return QuerierFuncs{
+			Next: next,
+			QueryFunc: func(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+				queries = append(queries, sql)
+				return next.Query(ctx, sql, args...)
+			},
+		}
+	}
+
+	type testStruct struct {
+		A string `db:"a"`
+	}
+
+	var val []testStruct
+	ctx := context.Background()
+	err := Query(ctx, Chain(Pool(db), logging), &val, `SELECT 'a' as a`)
+	require.NoError(t, err)
+	require.Equal(t, []testStruct{{A: "a"}}, val)
+	require.Equal(t, []string{`SELECT 'a' as a`}, queries)
+}
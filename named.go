@@ -0,0 +1,36 @@
+package pgxscan
+
+import (
+	"context"
+
+	"github.com/Oliver-Fish/pgxscan/internal/mapping"
+)
+
+//NamedQueryRow is QueryRow for queries using :name parameters
+//The parameters are bound from the db tags of a struct or the keys of a map[string]interface{}
+func NamedQueryRow(ctx context.Context, tx Querier, input interface{}, query string, arg interface{}) error {
+	query, args, err := Named(query, arg)
+	if err != nil {
+		return err
+	}
+
+	return QueryRow(ctx, tx, input, query, args...)
+}
+
+//NamedQuery is Query for queries using :name parameters
+//The parameters are bound from the db tags of a struct or the keys of a map[string]interface{}
+func NamedQuery(ctx context.Context, tx Querier, input interface{}, query string, arg interface{}) error {
+	query, args, err := Named(query, arg)
+	if err != nil {
+		return err
+	}
+
+	return Query(ctx, tx, input, query, args...)
+}
+
+//Named rewrites the :name parameters in query to $n placeholders and returns the ordered args for them
+//arg must be a struct, a pointer to a struct or a map with string keys
+//Quoted strings, quoted identifiers, comments, :: casts, array slices and dollar quoted bodies are left untouched
+func Named(query string, arg interface{}) (string, []interface{}, error) {
+	return mapping.Named(query, arg)
+}
@@ -0,0 +1,27 @@
+package pgxscan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamedQueryRow(t *testing.T) {
+	type testStruct struct {
+		A string `db:"a"`
+		B int    `db:"b"`
+	}
+
+	var val testStruct
+	ctx := context.Background()
+	err := NamedQueryRow(ctx, db, &val,
+		`
+	SELECT
+		:a::text as a,
+		:b::int as b
+	`, testStruct{A: "something", B: 1})
+
+	require.NoError(t, err)
+	require.Equal(t, testStruct{A: "something", B: 1}, val)
+}
@@ -2,96 +2,36 @@ package pgxscan
 
 import (
 	"context"
-	"fmt"
 	"reflect"
 
+	"github.com/Oliver-Fish/pgxscan/internal/mapping"
 	"github.com/jackc/pgx/v4"
 )
 
-type querier interface {
+//Querier is the connection QueryRow and the slice helpers run their queries on
+//It is implemented by *pgx.Conn, *pgxpool.Pool and pgx.Tx and can be decorated with Middleware
+type Querier interface {
 	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
 }
 
-func getDBTagPositions(rt reflect.Type) (map[string][]int, error) {
-	if rt.Kind() != reflect.Struct {
-		return nil, fmt.Errorf("reflect type is not a struct")
-	}
-
-	tagPositions := make(map[string][]int)
-
-	for i := 0; i < rt.NumField(); i++ {
-		field := rt.Field(i)
-
-		switch field.Type.Kind() {
-		case reflect.Struct:
-			tag := field.Tag.Get("db")
-			if tag == "-" {
-				//If an embeded struct has a ignore db tag
-				//skip entire struct lookup, in this case we shouldn't have a tag
-				continue
-			}
-			if tag != "" {
-				//Tag Found so add it to the list and don't go deeper
-				tagPositions[tag] = field.Index
-				continue
-			}
-
-			//Get all tags on nested struct
-			nestedTags, err := getDBTagPositions(field.Type)
-			if err != nil {
-				return nil, err
-			}
-
-			//Add all nested positions to top level map
-			for t, ni := range nestedTags {
-				tagPositions[t] = append([]int{i}, ni...)
-			}
-
-		case reflect.Ptr:
-			tag := field.Tag.Get("db")
-			if tag == "-" {
-				//If an embeded struct has a ignore db tag
-				//skip entire struct lookup, in this case we shouldn't have a tag
-				continue
-			}
-			if tag != "" {
-				//Tag Found so add it to the list and don't go deeper
-				tagPositions[tag] = field.Index
-				continue
-			}
-			underlineType := field.Type.Elem()
-			if underlineType.Kind() == reflect.Struct {
-				//Get all tags on nested struct
-				nestedTags, err := getDBTagPositions(underlineType)
-				if err != nil {
-					return nil, err
-				}
-
-				//Add all nested positions to top level map
-				for t, ni := range nestedTags {
-					tagPositions[t] = append([]int{i}, ni...)
-				}
-				continue
-			}
-			//If we have a pointer that doesn't point to a struct then we don't need to look deeper
-			fallthrough
-		default:
-			tag := field.Tag.Get("db")
-			//If we find a case where no tag is set return error
-			//tags should either be set or have a dash to be ignored
-			if tag == "" {
-				return nil, fmt.Errorf("unset tag on property %d of struct %s", i, rt.String())
-			}
-
-			if tag == "-" {
-				continue
-			}
-
-			tagPositions[tag] = field.Index
+//pgxRows adapts pgx.Rows to the rows scanned by the mapping package
+type pgxRows struct {
+	pgx.Rows
+}
 
-		}
+func (rows pgxRows) Columns() []string {
+	headers := rows.FieldDescriptions()
+	columns := make([]string, len(headers))
+	for i, header := range headers {
+		columns[i] = string(header.Name)
 	}
 
-	return tagPositions, nil
+	return columns
+}
+
+//RegisterScanner makes Rows and QueryRow use a generated scanner for the struct type input points to
+//It is called by the init function of code generated by pgxscan-gen
+func RegisterScanner(input interface{}, scanner GeneratedScanner) {
+	mapping.RegisterScanner(reflect.TypeOf(input).Elem(), scanner)
 }
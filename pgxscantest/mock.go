@@ -0,0 +1,125 @@
+package pgxscantest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/jackc/pgx/v4"
+)
+
+//Mock is a Querier checking every query against the expectations set on it, in order
+//The zero value expects no queries
+type Mock struct {
+	expectations []*Expectation
+	pos          int
+	failure      error
+}
+
+//Expectation is a query expected by a Mock and the result it answers with
+type Expectation struct {
+	source    string
+	pattern   *regexp.Regexp
+	args      []interface{}
+	checkArgs bool
+	rows      pgx.Rows
+	err       error
+	setupErr  error
+}
+
+//ExpectQuery adds an expectation for a query whose SQL matches the regular expression pattern
+func (m *Mock) ExpectQuery(pattern string) *Expectation {
+	e := &Expectation{source: pattern}
+	e.pattern, e.setupErr = regexp.Compile(pattern)
+	m.expectations = append(m.expectations, e)
+	return e
+}
+
+//WithArgs makes the expectation only match queries with exactly these args
+func (e *Expectation) WithArgs(args ...interface{}) *Expectation {
+	e.args = args
+	e.checkArgs = true
+	return e
+}
+
+//WillReturnRows answers the query with rows
+func (e *Expectation) WillReturnRows(rows pgx.Rows) *Expectation {
+	e.rows = rows
+	return e
+}
+
+//WillReturnStructs answers the query with rows built from db tagged structs, see NewStructRows
+func (e *Expectation) WillReturnStructs(values interface{}) *Expectation {
+	rows, err := NewStructRows(values)
+	if err != nil {
+		e.setupErr = err
+	}
+	e.rows = rows
+	return e
+}
+
+//WillReturnError fails the query with err
+func (e *Expectation) WillReturnError(err error) *Expectation {
+	e.err = err
+	return e
+}
+
+func (m *Mock) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	if m.failure != nil {
+		return nil, m.failure
+	}
+
+	if m.pos >= len(m.expectations) {
+		return nil, m.fail("unexpected query %s with args %v", sql, args)
+	}
+
+	e := m.expectations[m.pos]
+	if e.setupErr != nil {
+		return nil, m.fail("expectation %d is invalid: %s", m.pos, e.setupErr)
+	}
+	if !e.pattern.MatchString(sql) {
+		return nil, m.fail("query %s does not match expectation %d: %s", sql, m.pos, e.source)
+	}
+	if e.checkArgs && !reflect.DeepEqual(e.args, args) {
+		return nil, m.fail("query %s args %v do not match expectation %d args %v", sql, args, m.pos, e.args)
+	}
+	m.pos++
+
+	if e.err != nil {
+		return nil, e.err
+	}
+	if e.rows == nil {
+		//An expectation without a result answers with no columns and no rows
+		return NewRows(nil)
+	}
+
+	return e.rows, nil
+}
+
+func (m *Mock) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	rows, err := m.Query(ctx, sql, args...)
+	return Row{
+		rows: rows,
+		err:  err,
+	}
+}
+
+//ExpectationsWereMet returns an error if a query was unexpected or an expectation was not consumed
+func (m *Mock) ExpectationsWereMet() error {
+	if m.failure != nil {
+		return m.failure
+	}
+
+	if m.pos < len(m.expectations) {
+		return fmt.Errorf("%d expected queries were not run, next: %s", len(m.expectations)-m.pos, m.expectations[m.pos].source)
+	}
+
+	return nil
+}
+
+//fail records the first failure so it is reported by ExpectationsWereMet even if the caller ignores it
+func (m *Mock) fail(format string, args ...interface{}) error {
+	m.failure = fmt.Errorf(format, args...)
+	return m.failure
+}
@@ -0,0 +1,82 @@
+package pgxscantest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Oliver-Fish/pgxscan"
+	"github.com/Oliver-Fish/pgxscan/pgxscantest"
+	"github.com/stretchr/testify/require"
+)
+
+type mockUser struct {
+	ID        int        `db:"id"`
+	Name      string     `db:"name"`
+	DeletedAt *time.Time `db:"deleted_at"`
+	Secret    string     `db:"-"`
+}
+
+func TestMock(t *testing.T) {
+	users := []mockUser{
+		{ID: 1, Name: "a"},
+		{ID: 2, Name: "b"},
+	}
+	errQuery := errors.New("query failed")
+
+	var mock pgxscantest.Mock
+	mock.ExpectQuery(`^SELECT .* FROM users$`).WillReturnStructs(users)
+	mock.ExpectQuery(`WHERE id = \$1`).WithArgs(1).WillReturnStructs(users[0])
+	mock.ExpectQuery(`FROM missing`).WillReturnError(errQuery)
+
+	ctx := context.Background()
+
+	var all []mockUser
+	require.NoError(t, pgxscan.Query(ctx, &mock, &all, `SELECT id, name, deleted_at FROM users`))
+	require.Equal(t, users, all)
+
+	var one mockUser
+	require.NoError(t, pgxscan.QueryRow(ctx, &mock, &one, `SELECT id, name, deleted_at FROM users WHERE id = $1`, 1))
+	require.Equal(t, users[0], one)
+
+	require.EqualError(t, mock.ExpectationsWereMet(), "1 expected queries were not run, next: FROM missing")
+
+	require.Equal(t, errQuery, pgxscan.Query(ctx, &mock, &all, `SELECT id FROM missing`))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMockUnexpected(t *testing.T) {
+	var mock pgxscantest.Mock
+	mock.ExpectQuery(`FROM users`).WithArgs(1)
+
+	ctx := context.Background()
+	var one mockUser
+
+	err := pgxscan.QueryRow(ctx, &mock, &one, `SELECT id FROM users WHERE id = $1`, 2)
+	require.EqualError(t, err, "query SELECT id FROM users WHERE id = $1 args [2] do not match expectation 0 args [1]")
+	require.Equal(t, err, mock.ExpectationsWereMet())
+
+	mock = pgxscantest.Mock{}
+	err = pgxscan.QueryRow(ctx, &mock, &one, `SELECT id FROM users`)
+	require.EqualError(t, err, "unexpected query SELECT id FROM users with args []")
+}
+
+func TestNewStructRows(t *testing.T) {
+	deletedAt := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	rows, err := pgxscantest.NewStructRows([]*mockUser{{ID: 1, Name: "a", DeletedAt: &deletedAt, Secret: "s"}})
+	require.NoError(t, err)
+
+	var names []string
+	for _, field := range rows.FieldDescriptions() {
+		names = append(names, string(field.Name))
+	}
+	require.Equal(t, []string{"id", "name", "deleted_at"}, names)
+
+	require.True(t, rows.Next())
+	values, err := rows.Values()
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{int64(1), "a"}, values[:2])
+	require.True(t, deletedAt.Equal(values[2].(time.Time)))
+}
@@ -0,0 +1,94 @@
+package pgxscantest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v4"
+)
+
+//Querier is a fake pgxscan.Querier answering each query with the next queued result
+type Querier struct {
+	results []result
+
+	//Queries holds every query received, in order
+	Queries []Query
+}
+
+//Query is a query received by a Querier
+type Query struct {
+	SQL  string
+	Args []interface{}
+}
+
+type result struct {
+	rows pgx.Rows
+	err  error
+}
+
+//Return queues rows as the result of the next query
+func (q *Querier) Return(rows pgx.Rows) {
+	q.results = append(q.results, result{rows: rows})
+}
+
+//ReturnError queues err as the result of the next query
+func (q *Querier) ReturnError(err error) {
+	q.results = append(q.results, result{err: err})
+}
+
+func (q *Querier) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	q.Queries = append(q.Queries, Query{
+		SQL:  sql,
+		Args: args,
+	})
+
+	if len(q.results) == 0 {
+		return nil, fmt.Errorf("unexpected query, no result queued: %s", sql)
+	}
+
+	next := q.results[0]
+	q.results = q.results[1:]
+
+	return next.rows, next.err
+}
+
+func (q *Querier) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	rows, err := q.Query(ctx, sql, args...)
+	return Row{
+		rows: rows,
+		err:  err,
+	}
+}
+
+//Row is a pgx.Row reading the first row of a pgx.Rows
+type Row struct {
+	rows pgx.Rows
+	err  error
+}
+
+//NewRow returns a pgx.Row reading the first row of rows
+func NewRow(rows pgx.Rows) Row {
+	return Row{rows: rows}
+}
+
+func (r Row) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	defer r.rows.Close()
+
+	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			return err
+		}
+		return pgx.ErrNoRows
+	}
+
+	err := r.rows.Scan(dest...)
+	if err != nil {
+		return err
+	}
+
+	r.rows.Close()
+	return r.rows.Err()
+}
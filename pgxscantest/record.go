@@ -0,0 +1,236 @@
+package pgxscantest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Oliver-Fish/pgxscan"
+	"github.com/jackc/pgproto3/v2"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+)
+
+//recording is a query and its result as stored in a recording file
+type recording struct {
+	SQL       string           `json:"sql"`
+	Args      json.RawMessage  `json:"args"`
+	Columns   []recordedColumn `json:"columns,omitempty"`
+	Rows      [][][]byte       `json:"rows,omitempty"`
+	Error     string           `json:"error,omitempty"`
+	RowsError string           `json:"rows_error,omitempty"`
+}
+
+type recordedColumn struct {
+	Name   string `json:"name"`
+	OID    uint32 `json:"oid"`
+	Format int16  `json:"format"`
+}
+
+//Recorder is a Querier passing every query to a real connection and recording it with its result
+//Call Save to write the recordings to be replayed by a Replayer
+type Recorder struct {
+	next       pgxscan.Querier
+	path       string
+	recordings []recording
+}
+
+//NewRecorder returns a Recorder running queries on next, Save writes the recordings to path
+func NewRecorder(next pgxscan.Querier, path string) *Recorder {
+	return &Recorder{
+		next: next,
+		path: path,
+	}
+}
+
+func (r *Recorder) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	encodedArgs, err := json.Marshal(args)
+	if err != nil {
+		return nil, fmt.Errorf("unable to record args of query %s: %w", sql, err)
+	}
+
+	rec := recording{
+		SQL:  sql,
+		Args: encodedArgs,
+	}
+
+	rows, err := r.next.Query(ctx, sql, args...)
+	if err != nil {
+		rec.Error = err.Error()
+		r.recordings = append(r.recordings, rec)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for _, field := range rows.FieldDescriptions() {
+		rec.Columns = append(rec.Columns, recordedColumn{
+			Name:   string(field.Name),
+			OID:    field.DataTypeOID,
+			Format: field.Format,
+		})
+	}
+
+	for rows.Next() {
+		raw := rows.RawValues()
+		values := make([][]byte, len(raw))
+		for i, value := range raw {
+			if value != nil {
+				values[i] = append([]byte{}, value...)
+			}
+		}
+		rec.Rows = append(rec.Rows, values)
+	}
+	if err := rows.Err(); err != nil {
+		rec.RowsError = err.Error()
+	}
+
+	r.recordings = append(r.recordings, rec)
+
+	return rec.replay(), nil
+}
+
+func (r *Recorder) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	rows, err := r.Query(ctx, sql, args...)
+	return Row{
+		rows: rows,
+		err:  err,
+	}
+}
+
+//Save writes every recorded query to the recording file
+func (r *Recorder) Save() error {
+	data, err := json.MarshalIndent(r.recordings, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.path, data, 0644)
+}
+
+//Replayer is a Querier answering queries from a recording file without a database
+//Queries must arrive in the recorded order with the recorded args, anything else fails with ErrReplayMismatch
+type Replayer struct {
+	recordings []recording
+	pos        int
+	mismatch   error
+}
+
+//ErrReplayMismatch is returned when a replayed query or its args differ from the recording
+type ErrReplayMismatch struct {
+	Index    int
+	Expected string
+	Actual   string
+}
+
+func (err ErrReplayMismatch) Error() string {
+	return fmt.Sprintf("replayed query %d does not match the recording\nexpected: %s\nactual:   %s", err.Index, err.Expected, err.Actual)
+}
+
+//NewReplayer reads the recordings written by a Recorder at path
+func NewReplayer(path string) (*Replayer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var recordings []recording
+	err = json.Unmarshal(data, &recordings)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read recording %s: %w", path, err)
+	}
+
+	//Args are indented by Save, compact them to compare with the args of replayed queries
+	for i := range recordings {
+		var args bytes.Buffer
+		err = json.Compact(&args, recordings[i].Args)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read args of recording %d: %w", i, err)
+		}
+		recordings[i].Args = args.Bytes()
+	}
+
+	return &Replayer{
+		recordings: recordings,
+	}, nil
+}
+
+func (r *Replayer) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	if r.mismatch != nil {
+		return nil, r.mismatch
+	}
+
+	encodedArgs, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+	actual := fmt.Sprintf("%s %s", sql, encodedArgs)
+
+	if r.pos >= len(r.recordings) {
+		r.mismatch = ErrReplayMismatch{
+			Index:    r.pos,
+			Expected: "no more queries",
+			Actual:   actual,
+		}
+		return nil, r.mismatch
+	}
+
+	rec := r.recordings[r.pos]
+	if rec.SQL != sql || string(rec.Args) != string(encodedArgs) {
+		r.mismatch = ErrReplayMismatch{
+			Index:    r.pos,
+			Expected: fmt.Sprintf("%s %s", rec.SQL, rec.Args),
+			Actual:   actual,
+		}
+		return nil, r.mismatch
+	}
+	r.pos++
+
+	if rec.Error != "" {
+		return nil, errors.New(rec.Error)
+	}
+
+	return rec.replay(), nil
+}
+
+func (r *Replayer) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	rows, err := r.Query(ctx, sql, args...)
+	return Row{
+		rows: rows,
+		err:  err,
+	}
+}
+
+//Done returns an error if a query did not match the recording or recorded queries were never replayed
+func (r *Replayer) Done() error {
+	if r.mismatch != nil {
+		return r.mismatch
+	}
+
+	if r.pos < len(r.recordings) {
+		return fmt.Errorf("%d recorded queries were not replayed, next: %s", len(r.recordings)-r.pos, r.recordings[r.pos].SQL)
+	}
+
+	return nil
+}
+
+//replay returns the recorded result as Rows
+func (rec recording) replay() *Rows {
+	fields := make([]pgproto3.FieldDescription, len(rec.Columns))
+	for i, column := range rec.Columns {
+		fields[i] = pgproto3.FieldDescription{
+			Name:        []byte(column.Name),
+			DataTypeOID: column.OID,
+			Format:      column.Format,
+		}
+	}
+
+	rows := newRawRows(pgtype.NewConnInfo(), fields, rec.Rows)
+	if rec.RowsError != "" {
+		rows.SetErr(errors.New(rec.RowsError))
+	}
+
+	return rows
+}
@@ -0,0 +1,91 @@
+package pgxscantest_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/Oliver-Fish/pgxscan"
+	"github.com/Oliver-Fish/pgxscan/pgxscantest"
+	"github.com/jackc/pgtype"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordReplay(t *testing.T) {
+	type testStruct struct {
+		A int     `db:"a"`
+		B *string `db:"b"`
+	}
+
+	rows, err := pgxscantest.NewRows(
+		[]pgxscantest.Column{{Name: "a", OID: pgtype.Int4OID}, {Name: "b"}},
+		[]interface{}{1, "b"},
+		[]interface{}{2, nil},
+	)
+	require.NoError(t, err)
+
+	var q pgxscantest.Querier
+	q.Return(rows)
+	q.ReturnError(errors.New("relation does not exist"))
+
+	path := filepath.Join(t.TempDir(), "recording.json")
+	recorder := pgxscantest.NewRecorder(&q, path)
+
+	ctx := context.Background()
+	expected := []testStruct{{A: 1, B: stringPtr("b")}, {A: 2}}
+
+	var recorded []testStruct
+	err = pgxscan.Query(ctx, recorder, &recorded, `SELECT a, b FROM t WHERE a > $1`, 0)
+	require.NoError(t, err)
+	require.Equal(t, expected, recorded)
+
+	err = pgxscan.Query(ctx, recorder, &recorded, `SELECT a, b FROM missing`)
+	require.EqualError(t, err, "relation does not exist")
+
+	require.NoError(t, recorder.Save())
+
+	replayer, err := pgxscantest.NewReplayer(path)
+	require.NoError(t, err)
+
+	var replayed []testStruct
+	err = pgxscan.Query(ctx, replayer, &replayed, `SELECT a, b FROM t WHERE a > $1`, 0)
+	require.NoError(t, err)
+	require.Equal(t, expected, replayed)
+
+	require.EqualError(t, replayer.Done(), "1 recorded queries were not replayed, next: SELECT a, b FROM missing")
+
+	err = pgxscan.Query(ctx, replayer, &replayed, `SELECT a, b FROM missing`)
+	require.EqualError(t, err, "relation does not exist")
+	require.NoError(t, replayer.Done())
+}
+
+func TestReplayMismatch(t *testing.T) {
+	rows, err := pgxscantest.NewRows([]pgxscantest.Column{{Name: "a"}}, []interface{}{"a"})
+	require.NoError(t, err)
+
+	var q pgxscantest.Querier
+	q.Return(rows)
+
+	path := filepath.Join(t.TempDir(), "recording.json")
+	recorder := pgxscantest.NewRecorder(&q, path)
+
+	ctx := context.Background()
+	var val struct {
+		A string `db:"a"`
+	}
+	require.NoError(t, pgxscan.QueryRow(ctx, recorder, &val, `SELECT $1::text as a`, "a"))
+	require.NoError(t, recorder.Save())
+
+	replayer, err := pgxscantest.NewReplayer(path)
+	require.NoError(t, err)
+
+	expectedErr := pgxscantest.ErrReplayMismatch{
+		Index:    0,
+		Expected: `SELECT $1::text as a ["a"]`,
+		Actual:   `SELECT $1::text as a ["b"]`,
+	}
+	err = pgxscan.QueryRow(ctx, replayer, &val, `SELECT $1::text as a`, "b")
+	require.Equal(t, expectedErr, err)
+	require.Equal(t, expectedErr, replayer.Done())
+}
@@ -0,0 +1,225 @@
+//Package pgxscantest provides in-memory pgx.Rows and Querier fakes
+//Code using pgxscan, and pgxscan's own mapping, can be tested with them without a PostgreSQL server
+package pgxscantest
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgproto3/v2"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+)
+
+//Column describes a result column of Rows
+//If OID is zero it is inferred from the Go type of the first non NULL value in the column
+type Column struct {
+	Name string
+	OID  uint32
+}
+
+//Rows is an in-memory pgx.Rows
+//Values are encoded and decoded with the same pgtype conversions pgx uses for a real result set
+type Rows struct {
+	connInfo *pgtype.ConnInfo
+	fields   []pgproto3.FieldDescription
+	values   [][][]byte
+	row      int
+	closed   bool
+	err      error
+}
+
+var _ pgx.Rows = (*Rows)(nil)
+
+//NewRows builds Rows from columns and one slice of values per row, a nil value is NULL
+func NewRows(columns []Column, values ...[]interface{}) (*Rows, error) {
+	connInfo := pgtype.NewConnInfo()
+
+	fields := make([]pgproto3.FieldDescription, len(columns))
+	for i, column := range columns {
+		oid := column.OID
+		if oid == 0 {
+			oid = inferOID(connInfo, values, i)
+		}
+
+		fields[i] = pgproto3.FieldDescription{
+			Name:        []byte(column.Name),
+			DataTypeOID: oid,
+			Format:      connInfo.ResultFormatCodeForOID(oid),
+		}
+	}
+
+	raw := make([][][]byte, len(values))
+	for r, row := range values {
+		if len(row) != len(columns) {
+			return nil, fmt.Errorf("row %d has %d values for %d columns", r, len(row), len(columns))
+		}
+
+		raw[r] = make([][]byte, len(row))
+		for i, value := range row {
+			buf, err := encode(connInfo, fields[i], value)
+			if err != nil {
+				return nil, fmt.Errorf("row %d column %s: %w", r, columns[i].Name, err)
+			}
+			raw[r][i] = buf
+		}
+	}
+
+	return newRawRows(connInfo, fields, raw), nil
+}
+
+func newRawRows(connInfo *pgtype.ConnInfo, fields []pgproto3.FieldDescription, values [][][]byte) *Rows {
+	return &Rows{
+		connInfo: connInfo,
+		fields:   fields,
+		values:   values,
+		row:      -1,
+	}
+}
+
+//inferOID returns the OID for the first non NULL value of a column, columns of only NULLs are text
+func inferOID(connInfo *pgtype.ConnInfo, values [][]interface{}, column int) uint32 {
+	for _, row := range values {
+		if column >= len(row) || row[column] == nil {
+			continue
+		}
+		if dt, ok := connInfo.DataTypeForValue(row[column]); ok {
+			return dt.OID
+		}
+		break
+	}
+
+	return pgtype.TextOID
+}
+
+//encode converts value into the wire format of field, a nil buffer is NULL
+func encode(connInfo *pgtype.ConnInfo, field pgproto3.FieldDescription, value interface{}) ([]byte, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	dt, ok := connInfo.DataTypeForOID(field.DataTypeOID)
+	if !ok {
+		return nil, fmt.Errorf("unknown OID %d", field.DataTypeOID)
+	}
+
+	pgValue := pgtype.NewValue(dt.Value)
+	err := pgValue.Set(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if field.Format == pgx.BinaryFormatCode {
+		encoder, ok := pgValue.(pgtype.BinaryEncoder)
+		if !ok {
+			return nil, fmt.Errorf("OID %d has no binary encoder", field.DataTypeOID)
+		}
+		return encoder.EncodeBinary(connInfo, nil)
+	}
+
+	encoder, ok := pgValue.(pgtype.TextEncoder)
+	if !ok {
+		return nil, fmt.Errorf("OID %d has no text encoder", field.DataTypeOID)
+	}
+	return encoder.EncodeText(connInfo, nil)
+}
+
+//SetErr makes the rows fail with err once all rows have been read
+func (r *Rows) SetErr(err error) {
+	r.err = err
+}
+
+func (r *Rows) Close() {
+	r.closed = true
+}
+
+func (r *Rows) Err() error {
+	//Like a real result set the error is only seen once the rows before it have been read
+	if r.row < len(r.values) {
+		return nil
+	}
+
+	return r.err
+}
+
+func (r *Rows) CommandTag() pgconn.CommandTag {
+	return pgconn.CommandTag(fmt.Sprintf("SELECT %d", len(r.values)))
+}
+
+func (r *Rows) FieldDescriptions() []pgproto3.FieldDescription {
+	return r.fields
+}
+
+func (r *Rows) Next() bool {
+	if r.closed {
+		return false
+	}
+
+	r.row++
+	if r.row >= len(r.values) {
+		r.Close()
+		return false
+	}
+
+	return true
+}
+
+func (r *Rows) Scan(dest ...interface{}) error {
+	if r.closed || r.row < 0 {
+		return errors.New("no row to scan, call Next first")
+	}
+
+	return pgx.ScanRow(r.connInfo, r.fields, r.values[r.row], dest...)
+}
+
+func (r *Rows) Values() ([]interface{}, error) {
+	if r.closed || r.row < 0 {
+		return nil, errors.New("rows is closed")
+	}
+
+	values := make([]interface{}, len(r.fields))
+	for i, field := range r.fields {
+		buf := r.values[r.row][i]
+		if buf == nil {
+			continue
+		}
+
+		var value pgtype.Value
+		if dt, ok := r.connInfo.DataTypeForOID(field.DataTypeOID); ok {
+			value = pgtype.NewValue(dt.Value)
+		}
+
+		var err error
+		if field.Format == pgx.BinaryFormatCode {
+			decoder, ok := value.(pgtype.BinaryDecoder)
+			if !ok {
+				decoder = &pgtype.GenericBinary{}
+			}
+			err = decoder.DecodeBinary(r.connInfo, buf)
+			value = decoder.(pgtype.Value)
+		} else {
+			decoder, ok := value.(pgtype.TextDecoder)
+			if !ok {
+				decoder = &pgtype.GenericText{}
+			}
+			err = decoder.DecodeText(r.connInfo, buf)
+			value = decoder.(pgtype.Value)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		values[i] = value.Get()
+	}
+
+	return values, nil
+}
+
+func (r *Rows) RawValues() [][]byte {
+	if r.closed || r.row < 0 {
+		return nil
+	}
+
+	return r.values[r.row]
+}
@@ -0,0 +1,180 @@
+package pgxscantest_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Oliver-Fish/pgxscan"
+	"github.com/Oliver-Fish/pgxscan/pgxscantest"
+	"github.com/jackc/pgtype"
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func TestRows(t *testing.T) {
+	timeStamp := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	rows, err := pgxscantest.NewRows(
+		[]pgxscantest.Column{
+			{Name: "a"},
+			{Name: "b", OID: pgtype.Int4OID},
+			{Name: "c"},
+		},
+		[]interface{}{"a", 1, timeStamp},
+		[]interface{}{nil, nil, nil},
+	)
+	require.NoError(t, err)
+
+	fields := rows.FieldDescriptions()
+	require.Equal(t, uint32(pgtype.TextOID), fields[0].DataTypeOID)
+	require.Equal(t, uint32(pgtype.Int4OID), fields[1].DataTypeOID)
+	require.Equal(t, uint32(pgtype.TimestamptzOID), fields[2].DataTypeOID)
+
+	require.True(t, rows.Next())
+	values, err := rows.Values()
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"a", int32(1)}, values[:2])
+	require.True(t, timeStamp.Equal(values[2].(time.Time)))
+
+	require.True(t, rows.Next())
+	values, err = rows.Values()
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{nil, nil, nil}, values)
+
+	require.False(t, rows.Next())
+	require.NoError(t, rows.Err())
+	require.Equal(t, "SELECT 2", rows.CommandTag().String())
+}
+
+func TestRowsErr(t *testing.T) {
+	errRows := errors.New("connection lost")
+
+	rows, err := pgxscantest.NewRows([]pgxscantest.Column{{Name: "a"}}, []interface{}{"a"})
+	require.NoError(t, err)
+	rows.SetErr(errRows)
+
+	require.True(t, rows.Next())
+	require.NoError(t, rows.Err())
+	require.False(t, rows.Next())
+	require.Equal(t, errRows, rows.Err())
+}
+
+func TestQueryRow(t *testing.T) {
+	type Embedded struct {
+		C *int `db:"c"`
+	}
+	type testStruct struct {
+		A string  `db:"a"`
+		B *string `db:"b"`
+		*Embedded
+	}
+
+	tests := map[string]struct {
+		columns       []pgxscantest.Column
+		values        [][]interface{}
+		expected      testStruct
+		expectedError error
+	}{
+		"All Properties": {
+			columns: []pgxscantest.Column{{Name: "a"}, {Name: "b"}, {Name: "c", OID: pgtype.Int4OID}},
+			values:  [][]interface{}{{"a", "b", 1}},
+			expected: testStruct{
+				A:        "a",
+				B:        stringPtr("b"),
+				Embedded: &Embedded{C: intPtr(1)},
+			},
+		},
+		"Pointer Values set to NULL": {
+			columns: []pgxscantest.Column{{Name: "a"}, {Name: "b"}, {Name: "c", OID: pgtype.Int4OID}},
+			values:  [][]interface{}{{"a", nil, nil}},
+			expected: testStruct{
+				A:        "a",
+				Embedded: &Embedded{},
+			},
+		},
+		"Missing Column": {
+			columns:       []pgxscantest.Column{{Name: "a"}},
+			values:        [][]interface{}{{"a"}},
+			expected:      testStruct{A: "a"},
+			expectedError: pgxscan.ErrQueryColumnsTagsMismtach,
+		},
+		"Extra Column": {
+			columns:  []pgxscantest.Column{{Name: "a"}, {Name: "b"}, {Name: "c", OID: pgtype.Int4OID}, {Name: "d"}},
+			values:   [][]interface{}{{"a", "b", 1, "d"}},
+			expected: testStruct{A: "a", B: stringPtr("b"), Embedded: &Embedded{C: intPtr(1)}},
+			expectedError: &pgxscan.ErrQueryReturnedExtraColumns{
+				ValueType: "*pgxscantest_test.testStruct",
+				Columns:   []string{"d"},
+			},
+		},
+		"No Rows": {
+			columns:       []pgxscantest.Column{{Name: "a"}},
+			expectedError: pgx.ErrNoRows,
+		},
+	}
+
+	ctx := context.Background()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rows, err := pgxscantest.NewRows(tc.columns, tc.values...)
+			require.NoError(t, err)
+
+			var q pgxscantest.Querier
+			q.Return(rows)
+
+			var val testStruct
+			err = pgxscan.QueryRow(ctx, &q, &val, `SELECT`, 1)
+			require.Equal(t, tc.expectedError, err)
+			require.Equal(t, tc.expected, val)
+			require.Equal(t, []pgxscantest.Query{{SQL: `SELECT`, Args: []interface{}{1}}}, q.Queries)
+		})
+	}
+}
+
+func TestQuery(t *testing.T) {
+	type testStruct struct {
+		A int     `db:"a"`
+		B *string `db:"b"`
+	}
+
+	rows, err := pgxscantest.NewRows(
+		[]pgxscantest.Column{{Name: "a", OID: pgtype.Int4OID}, {Name: "b"}},
+		[]interface{}{1, "b"},
+		[]interface{}{2, nil},
+	)
+	require.NoError(t, err)
+
+	var q pgxscantest.Querier
+	q.Return(rows)
+
+	var val []testStruct
+	err = pgxscan.Query(context.Background(), &q, &val, `SELECT`)
+	require.NoError(t, err)
+	require.Equal(t, []testStruct{{A: 1, B: stringPtr("b")}, {A: 2}}, val)
+}
+
+func TestQueryError(t *testing.T) {
+	errQuery := errors.New("query failed")
+
+	var q pgxscantest.Querier
+	q.ReturnError(errQuery)
+
+	var val []struct {
+		A int `db:"a"`
+	}
+	err := pgxscan.Query(context.Background(), &q, &val, `SELECT`)
+	require.Equal(t, errQuery, err)
+
+	err = pgxscan.Query(context.Background(), &q, &val, `SELECT`)
+	require.EqualError(t, err, "unexpected query, no result queued: SELECT")
+}
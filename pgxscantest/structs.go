@@ -0,0 +1,94 @@
+package pgxscantest
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/Oliver-Fish/pgxscan/internal/mapping"
+	"github.com/jackc/pgtype"
+)
+
+//NewStructRows builds Rows from db tagged structs, the reverse of scanning them
+//values is a struct, a pointer to a struct or a slice of either, every db tag becomes a column
+//Column types are inferred from the field types so columns of only NULLs are still typed
+func NewStructRows(values interface{}) (*Rows, error) {
+	rv := reflect.ValueOf(values)
+	if !rv.IsValid() {
+		return nil, fmt.Errorf("values is invalid")
+	}
+
+	if rv.Kind() != reflect.Slice {
+		slice := reflect.MakeSlice(reflect.SliceOf(rv.Type()), 1, 1)
+		slice.Index(0).Set(rv)
+		rv = slice
+	}
+
+	rt := rv.Type().Elem()
+	if rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	if rt.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("values is not a struct or slice of struct")
+	}
+
+	dbTagPos, err := mapping.ReadTagPositions(rt)
+	if err != nil {
+		return nil, err
+	}
+
+	connInfo := pgtype.NewConnInfo()
+	tags := mapping.SortedDBTags(dbTagPos)
+	columns := make([]Column, len(tags))
+	for i, tag := range tags {
+		columns[i] = Column{
+			Name: tag,
+			OID:  oidForType(connInfo, rt.FieldByIndex(dbTagPos[tag]).Type),
+		}
+	}
+
+	rows := make([][]interface{}, rv.Len())
+	for r := range rows {
+		structVal := rv.Index(r)
+		if structVal.Kind() == reflect.Ptr {
+			if structVal.IsNil() {
+				return nil, fmt.Errorf("value %d is a nil pointer", r)
+			}
+			structVal = structVal.Elem()
+		}
+
+		rows[r] = make([]interface{}, len(tags))
+		for i, tag := range tags {
+			fieldVal, ok := mapping.FieldByIndex(structVal, dbTagPos[tag])
+			if !ok {
+				continue
+			}
+			if !fieldVal.CanInterface() {
+				return nil, mapping.ErrUnexportedProperty{
+					PropertyName: rt.FieldByIndex(dbTagPos[tag]).Name,
+				}
+			}
+			for fieldVal.Kind() == reflect.Ptr && !fieldVal.IsNil() {
+				fieldVal = fieldVal.Elem()
+			}
+			if fieldVal.Kind() == reflect.Ptr {
+				continue
+			}
+			rows[r][i] = fieldVal.Interface()
+		}
+	}
+
+	return NewRows(columns, rows...)
+}
+
+//oidForType returns the OID pgx would encode a value of type rt as, falling back to text
+func oidForType(connInfo *pgtype.ConnInfo, rt reflect.Type) uint32 {
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+
+	if dt, ok := connInfo.DataTypeForValue(reflect.Zero(rt).Interface()); ok {
+		return dt.OID
+	}
+
+	return pgtype.TextOID
+}
@@ -0,0 +1,29 @@
+//Package pgxv5 is pgxscan for github.com/jackc/pgx/v5
+//It shares its struct mapping with pgxscan so db tags behave the same with either pgx version
+package pgxv5
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type querier interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+//pgxRows adapts pgx.Rows to the rows scanned by the mapping package
+type pgxRows struct {
+	pgx.Rows
+}
+
+func (rows pgxRows) Columns() []string {
+	headers := rows.FieldDescriptions()
+	columns := make([]string, len(headers))
+	for i, header := range headers {
+		columns[i] = header.Name
+	}
+
+	return columns
+}
@@ -0,0 +1,24 @@
+package pgxv5
+
+import (
+	"context"
+
+	"github.com/Oliver-Fish/pgxscan/internal/mapping"
+	"github.com/jackc/pgx/v5"
+)
+
+//Query is a wrapper around Query that scans every returned row into a pointer to a slice of struct
+func Query(ctx context.Context, tx querier, input interface{}, query string, args ...interface{}) error {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return Rows(rows, input)
+}
+
+//Rows takes a pgx.Rows and pointer to a slice of struct
+//It will simplify scanning by using the db tags on structs to avoid verbose Scan calls
+func Rows(rows pgx.Rows, input interface{}) error {
+	return mapping.ScanRows(pgxRows{rows}, input)
+}
@@ -0,0 +1,28 @@
+package pgxv5
+
+import (
+	"context"
+
+	"github.com/Oliver-Fish/pgxscan/internal/mapping"
+	"github.com/jackc/pgx/v5"
+)
+
+//QueryRow is a wrapper around Query that allows us to avoid the verbose Scan call
+func QueryRow(ctx context.Context, tx querier, input interface{}, query string, args ...interface{}) error {
+	rv, dbTagPos, err := mapping.ValidateRowInput(input)
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	err = mapping.ScanRow(pgxRows{rows}, input, rv, dbTagPos)
+	if err == mapping.ErrNoRows {
+		return pgx.ErrNoRows
+	}
+
+	return err
+}
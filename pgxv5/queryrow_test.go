@@ -0,0 +1,84 @@
+package pgxv5
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryRow(t *testing.T) {
+	type Embedded struct {
+		C *string `db:"c"`
+	}
+	type testStruct struct {
+		A string    `db:"a"`
+		B time.Time `db:"b"`
+		*Embedded
+		D string `db:"-"`
+	}
+
+	tests := map[string]struct {
+		query         string
+		expected      testStruct
+		expectedError error
+	}{
+		"All Properties": {
+			query: `
+			SELECT
+				'a' as a,
+				TIMESTAMPTZ '2006-01-02T15:04:05Z' as b,
+				NULL::text as c
+			`,
+			expected: testStruct{
+				A:        "a",
+				B:        time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC),
+				Embedded: &Embedded{},
+			},
+		},
+		"Missing Column": {
+			query: `
+			SELECT
+				'a' as a
+			`,
+			expected: testStruct{
+				A: "a",
+			},
+			expectedError: ErrQueryColumnsTagsMismtach,
+		},
+		"No Rows": {
+			query: `
+			SELECT
+				'a' as a
+			WHERE false
+			`,
+			expectedError: pgx.ErrNoRows,
+		},
+	}
+
+	ctx := context.Background()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var val testStruct
+			err := QueryRow(ctx, db, &val, tc.query)
+			require.Equal(t, tc.expectedError, err)
+			require.Equal(t, tc.expected.A, val.A)
+			require.True(t, tc.expected.B.Equal(val.B))
+			require.Equal(t, tc.expected.Embedded, val.Embedded)
+		})
+	}
+}
+
+func TestQuery(t *testing.T) {
+	type testStruct struct {
+		A int `db:"a"`
+	}
+
+	var val []testStruct
+	ctx := context.Background()
+	err := Query(ctx, db, &val, `SELECT g as a FROM generate_series(1, 3) g`)
+	require.NoError(t, err)
+	require.Equal(t, []testStruct{{A: 1}, {A: 2}, {A: 3}}, val)
+}
@@ -0,0 +1,149 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Oliver-Fish/pgxscan/internal/mapping"
+	"github.com/jackc/pgx/v4"
+)
+
+//GetByPK scans the row of table whose primary key equals keys into dest, a pointer to a struct
+//The primary key is made of the fields tagged with the pk option, as in db:"id,pk", keys are passed in field order
+//Soft deleted rows are not found, GetByPKWithOptions takes WithDeleted to include them
+//pgx.ErrNoRows is returned if no row matches
+func GetByPK(ctx context.Context, q Querier, table string, dest interface{}, keys ...interface{}) error {
+	return GetByPKWithOptions(ctx, q, table, dest, keys)
+}
+
+//GetByPKWithOptions is GetByPK with the keys passed as a slice followed by opts
+func GetByPKWithOptions(ctx context.Context, q Querier, table string, dest interface{}, keys []interface{}, opts ...Option) error {
+	pk, err := primaryKeyWhere(dest, keys, opts)
+	if err != nil {
+		return err
+	}
+
+	columns, err := columnList(pk.rt, "")
+	if err != nil {
+		return err
+	}
+
+	return QueryRow(ctx, q, dest, "SELECT "+columns+" FROM "+tableIdentifier(table)+" WHERE "+pk.where(), keys...)
+}
+
+//DeleteByPK deletes the row of table whose primary key equals keys and returns the number of rows deleted
+//input is a pointer to the struct describing the table, only its type is used
+//If a field is tagged softdelete the row is marked deleted by setting its column to now() instead,
+//DeleteByPKWithOptions takes WithDeleted to delete it
+func DeleteByPK(ctx context.Context, q Querier, table string, input interface{}, keys ...interface{}) (int64, error) {
+	return DeleteByPKWithOptions(ctx, q, table, input, keys)
+}
+
+//DeleteByPKWithOptions is DeleteByPK with the keys passed as a slice followed by opts
+func DeleteByPKWithOptions(ctx context.Context, q Querier, table string, input interface{}, keys []interface{}, opts ...Option) (int64, error) {
+	pk, err := primaryKeyWhere(input, keys, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	if pk.softDelete != "" && !pk.opt.withDeleted {
+		return exec(ctx, q, "UPDATE "+tableIdentifier(table)+" SET "+pgx.Identifier{pk.softDelete}.Sanitize()+" = now() WHERE "+pk.where(), keys...)
+	}
+
+	return exec(ctx, q, "DELETE FROM "+tableIdentifier(table)+" WHERE "+pk.where(), keys...)
+}
+
+//ExistsByPK reports whether table has a row whose primary key equals keys
+//input is a pointer to the struct describing the table, only its type is used
+//Soft deleted rows don't exist, ExistsByPKWithOptions takes WithDeleted to include them
+func ExistsByPK(ctx context.Context, q Querier, table string, input interface{}, keys ...interface{}) (bool, error) {
+	return ExistsByPKWithOptions(ctx, q, table, input, keys)
+}
+
+//ExistsByPKWithOptions is ExistsByPK with the keys passed as a slice followed by opts
+func ExistsByPKWithOptions(ctx context.Context, q Querier, table string, input interface{}, keys []interface{}, opts ...Option) (bool, error) {
+	pk, err := primaryKeyWhere(input, keys, opts)
+	if err != nil {
+		return false, err
+	}
+
+	var exists bool
+	err = q.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM "+tableIdentifier(table)+" WHERE "+pk.where()+")", keys...).Scan(&exists)
+	return exists, err
+}
+
+//primaryKey is the primary key condition of a struct type
+type primaryKey struct {
+	rt         reflect.Type
+	conditions []string
+	softDelete string
+	opt        Option
+}
+
+//where returns the condition matching the primary key to the placeholders of the keys,
+//soft deleted rows are left out unless WithDeleted was passed
+func (pk primaryKey) where() string {
+	conditions := pk.conditions
+	if condition := notDeleted(pk.softDelete, pk.opt); condition != "" {
+		conditions = append(conditions[:len(conditions):len(conditions)], condition)
+	}
+
+	return strings.Join(conditions, " AND ")
+}
+
+//primaryKeyWhere returns the primary key of the struct type of input matched against keys
+func primaryKeyWhere(input interface{}, keys []interface{}, opts []Option) (primaryKey, error) {
+	rt, err := destinationStruct(input)
+	if err != nil {
+		return primaryKey{}, err
+	}
+
+	dbTagPos, err := mapping.DBTagPositions(rt)
+	if err != nil {
+		return primaryKey{}, err
+	}
+
+	softDelete, err := softDeleteColumn(rt, dbTagPos)
+	if err != nil {
+		return primaryKey{}, err
+	}
+
+	columns := mapping.TagsWithOption(rt, dbTagPos, "pk")
+	if len(columns) == 0 || len(columns) != len(keys) {
+		return primaryKey{}, ErrPrimaryKey{
+			ValueType: fmt.Sprintf("%T", input),
+			Columns:   columns,
+			Keys:      len(keys),
+		}
+	}
+
+	pk := primaryKey{
+		rt:         rt,
+		conditions: make([]string, len(columns)),
+		softDelete: softDelete,
+		opt:        mergeOptions(opts),
+	}
+	for i, column := range columns {
+		pk.conditions[i] = fmt.Sprintf("%s = $%d", pgx.Identifier{column}.Sanitize(), i+1)
+	}
+
+	return pk, nil
+}
+
+//exec runs a statement through q, which only exposes Query, and returns the number of rows it affected
+func exec(ctx context.Context, q Querier, sql string, args ...interface{}) (int64, error) {
+	rows, err := q.Query(ctx, sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	rows.Close()
+
+	err = rows.Err()
+	if err != nil {
+		return 0, err
+	}
+
+	return rows.CommandTag().RowsAffected(), nil
+}
@@ -0,0 +1,83 @@
+package pgxscan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+type pkTestStruct struct {
+	Org  int    `db:"org,pk"`
+	ID   int    `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+func TestPrimaryKeyHelpers(t *testing.T) {
+	ctx := context.Background()
+	_, err := db.Exec(ctx, `CREATE TABLE pk_helpers (org int, id int, name text, PRIMARY KEY (org, id))`)
+	require.NoError(t, err)
+	defer db.Exec(ctx, `DROP TABLE pk_helpers`)
+
+	_, err = db.Exec(ctx, `INSERT INTO pk_helpers VALUES (1, 1, 'a'), (1, 2, 'b'), (2, 1, 'c')`)
+	require.NoError(t, err)
+
+	var val pkTestStruct
+	err = GetByPK(ctx, db, "pk_helpers", &val, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, pkTestStruct{Org: 1, ID: 2, Name: "b"}, val)
+
+	err = GetByPK(ctx, db, "pk_helpers", &val, 3, 3)
+	require.Equal(t, pgx.ErrNoRows, err)
+
+	exists, err := ExistsByPK(ctx, db, "pk_helpers", &pkTestStruct{}, 2, 1)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	deleted, err := DeleteByPK(ctx, db, "pk_helpers", &pkTestStruct{}, 2, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	exists, err = ExistsByPK(ctx, db, "pk_helpers", &pkTestStruct{}, 2, 1)
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+func TestPrimaryKeyErrors(t *testing.T) {
+	type noPK struct {
+		ID int `db:"id"`
+	}
+
+	tests := map[string]struct {
+		input interface{}
+		keys  []interface{}
+		err   error
+	}{
+		"NoPrimaryKey": {
+			input: &noPK{},
+			keys:  []interface{}{1},
+			err: ErrPrimaryKey{
+				ValueType: "*pgxscan.noPK",
+				Keys:      1,
+			},
+		},
+		"MissingKey": {
+			input: &pkTestStruct{},
+			keys:  []interface{}{1},
+			err: ErrPrimaryKey{
+				ValueType: "*pgxscan.pkTestStruct",
+				Columns:   []string{"org", "id"},
+				Keys:      1,
+			},
+		},
+	}
+
+	ctx := context.Background()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := GetByPK(ctx, db, "pk_helpers", tc.input, tc.keys...)
+			require.Equal(t, tc.err, err)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+//Registry holds queries and their destinations so they can all be validated with Check at once
+//Validating at service start or in a test surfaces schema drift before the first request runs a query
+type Registry struct {
+	mu      sync.Mutex
+	queries []registeredQuery
+}
+
+type registeredQuery struct {
+	name  string
+	query string
+	input interface{}
+}
+
+//DefaultRegistry is the Registry used by Register and ValidateAll
+var DefaultRegistry = &Registry{}
+
+//Register adds query to DefaultRegistry, see Registry.Register
+func Register(name, query string, input interface{}) string {
+	return DefaultRegistry.Register(name, query, input)
+}
+
+//ValidateAll validates every query in DefaultRegistry, see Registry.ValidateAll
+func ValidateAll(ctx context.Context, conn preparer) error {
+	return DefaultRegistry.ValidateAll(ctx, conn)
+}
+
+//Register adds query under name with the destination it is scanned into
+//input only provides the destination type so a typed nil such as (*User)(nil) or (*[]User)(nil) is enough
+//query is returned to allow registering where the query is declared
+func (r *Registry) Register(name, query string, input interface{}) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.queries = append(r.queries, registeredQuery{
+		name:  name,
+		query: query,
+		input: input,
+	})
+
+	return query
+}
+
+//ValidateAll checks every registered query against its destination on conn
+//Every failing query is reported in a single ErrRegistry
+func (r *Registry) ValidateAll(ctx context.Context, conn preparer) error {
+	r.mu.Lock()
+	queries := append([]registeredQuery{}, r.queries...)
+	r.mu.Unlock()
+
+	var registryErr ErrRegistry
+	names := make(map[string]bool, len(queries))
+	for _, q := range queries {
+		var err error
+		if names[q.name] {
+			err = fmt.Errorf("query name registered more than once")
+		} else {
+			err = Check(ctx, conn, q.input, q.query)
+		}
+		names[q.name] = true
+
+		if err != nil {
+			registryErr.Queries = append(registryErr.Queries, ErrRegisteredQuery{
+				Name:  q.name,
+				Query: q.query,
+				Err:   err,
+			})
+		}
+	}
+
+	if len(registryErr.Queries) > 0 {
+		return registryErr
+	}
+
+	return nil
+}
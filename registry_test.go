@@ -0,0 +1,43 @@
+package pgxscan
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryValidateAll(t *testing.T) {
+	type testStruct struct {
+		A string `db:"a"`
+		B int    `db:"b"`
+	}
+
+	var r Registry
+	query := r.Register("valid", `SELECT 'a'::text as a, 1 as b`, (*testStruct)(nil))
+	require.Equal(t, `SELECT 'a'::text as a, 1 as b`, query)
+	r.Register("valid slice", `SELECT 'a'::text as a, g as b FROM generate_series(1, 2) g`, (*[]testStruct)(nil))
+	r.Register("missing column", `SELECT 'a'::text as a`, (*testStruct)(nil))
+	r.Register("valid", `SELECT 'a'::text as a, 1 as b`, (*testStruct)(nil))
+
+	ctx := context.Background()
+	conn, err := db.Acquire(ctx)
+	require.NoError(t, err)
+	defer conn.Release()
+
+	err = r.ValidateAll(ctx, conn.Conn())
+	require.Error(t, err)
+
+	registryErr, ok := err.(ErrRegistry)
+	require.True(t, ok)
+	require.Len(t, registryErr.Queries, 2)
+
+	require.Equal(t, "missing column", registryErr.Queries[0].Name)
+	require.Equal(t, ErrMappingMismatch{
+		ValueType:      "*pgxscan.testStruct",
+		MissingColumns: []string{"b"},
+	}, registryErr.Queries[0].Err)
+
+	require.Equal(t, "valid", registryErr.Queries[1].Name)
+	require.EqualError(t, registryErr.Queries[1].Err, "query name registered more than once")
+}
@@ -0,0 +1,52 @@
+package pgxscan
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/Oliver-Fish/pgxscan/internal/mapping"
+	"github.com/jackc/pgx/v4"
+)
+
+//Option changes the queries built by SelectAll and the primary key helpers
+type Option struct {
+	withDeleted bool
+}
+
+//WithDeleted includes soft deleted rows in SelectAll, GetByPKWithOptions and ExistsByPKWithOptions
+//and makes DeleteByPKWithOptions delete the row instead of marking it deleted
+var WithDeleted = Option{withDeleted: true}
+
+//mergeOptions returns the combination of opts
+func mergeOptions(opts []Option) Option {
+	var merged Option
+	for _, opt := range opts {
+		merged.withDeleted = merged.withDeleted || opt.withDeleted
+	}
+
+	return merged
+}
+
+//softDeleteColumn returns the db tag of the field of rt tagged softdelete, as in db:"deleted_at,softdelete"
+//The column holds the time the row was deleted and is NULL for rows that are not deleted
+//It returns an empty string if no field is tagged softdelete
+func softDeleteColumn(rt reflect.Type, dbTagPos map[string][]int) (string, error) {
+	columns := mapping.TagsWithOption(rt, dbTagPos, "softdelete")
+	if len(columns) > 1 {
+		return "", fmt.Errorf("struct %s has more than one field tagged softdelete", rt.String())
+	}
+	if len(columns) == 0 {
+		return "", nil
+	}
+
+	return columns[0], nil
+}
+
+//notDeleted returns the condition excluding soft deleted rows, empty if column is empty or opt includes them
+func notDeleted(column string, opt Option) string {
+	if column == "" || opt.withDeleted {
+		return ""
+	}
+
+	return pgx.Identifier{column}.Sanitize() + " IS NULL"
+}
@@ -0,0 +1,68 @@
+package pgxscan
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/require"
+)
+
+type softDeleteTestStruct struct {
+	ID        int        `db:"id,pk"`
+	Name      string     `db:"name"`
+	DeletedAt *time.Time `db:"deleted_at,softdelete"`
+}
+
+func TestSoftDeleteSelectAll(t *testing.T) {
+	query, err := SelectAll[softDeleteTestStruct]("soft_delete")
+	require.NoError(t, err)
+	require.Equal(t, `SELECT "id", "name", "deleted_at" FROM "soft_delete" WHERE "deleted_at" IS NULL`, query)
+
+	query, err = SelectAll[softDeleteTestStruct]("soft_delete", WithDeleted)
+	require.NoError(t, err)
+	require.Equal(t, `SELECT "id", "name", "deleted_at" FROM "soft_delete"`, query)
+}
+
+func TestSoftDelete(t *testing.T) {
+	ctx := context.Background()
+	_, err := db.Exec(ctx, `CREATE TABLE soft_delete (id int PRIMARY KEY, name text, deleted_at timestamptz)`)
+	require.NoError(t, err)
+	defer db.Exec(ctx, `DROP TABLE soft_delete`)
+
+	_, err = db.Exec(ctx, `INSERT INTO soft_delete VALUES (1, 'a', NULL), (2, 'b', NULL)`)
+	require.NoError(t, err)
+
+	deleted, err := DeleteByPK(ctx, db, "soft_delete", &softDeleteTestStruct{}, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	var val softDeleteTestStruct
+	err = GetByPK(ctx, db, "soft_delete", &val, 1)
+	require.Equal(t, pgx.ErrNoRows, err)
+
+	err = GetByPKWithOptions(ctx, db, "soft_delete", &val, []interface{}{1}, WithDeleted)
+	require.NoError(t, err)
+	require.Equal(t, "a", val.Name)
+	require.NotNil(t, val.DeletedAt)
+
+	exists, err := ExistsByPK(ctx, db, "soft_delete", &softDeleteTestStruct{}, 1)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	query, err := SelectAll[softDeleteTestStruct]("soft_delete")
+	require.NoError(t, err)
+	var rows []softDeleteTestStruct
+	err = Query(ctx, db, &rows, query)
+	require.NoError(t, err)
+	require.Equal(t, []softDeleteTestStruct{{ID: 2, Name: "b"}}, rows)
+
+	deleted, err = DeleteByPKWithOptions(ctx, db, "soft_delete", &softDeleteTestStruct{}, []interface{}{1}, WithDeleted)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	exists, err = ExistsByPKWithOptions(ctx, db, "soft_delete", &softDeleteTestStruct{}, []interface{}{1}, WithDeleted)
+	require.NoError(t, err)
+	require.False(t, exists)
+}
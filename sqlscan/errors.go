@@ -0,0 +1,20 @@
+package sqlscan
+
+import (
+	"github.com/Oliver-Fish/pgxscan/internal/mapping"
+)
+
+// ErrQueryColumnsTagsMismtach is returned when not all struct tags count does not match query column count
+// This is a non-fatal error and can be ignored if the above is by design or planned
+// This however acts as a fail-safe to avoid missing columns inside your select calls
+var ErrQueryColumnsTagsMismtach = mapping.ErrQueryColumnsTagsMismtach
+
+type ErrUnexportedProperty = mapping.ErrUnexportedProperty
+
+type ErrNamedParameterMissing = mapping.ErrNamedParameterMissing
+
+type ErrQueryReturnedExtraColumns = mapping.ErrQueryReturnedExtraColumns
+
+//ErrInvalidTagOption is returned when the db tag of a field has an unknown or malformed option,
+//the options are listed in the [github.com/Oliver-Fish/pgxscan] package documentation
+type ErrInvalidTagOption = mapping.ErrInvalidTagOption
@@ -0,0 +1,24 @@
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Oliver-Fish/pgxscan/internal/mapping"
+)
+
+//Query is a wrapper around QueryContext that scans every returned row into a pointer to a slice of struct
+func Query(ctx context.Context, db querier, input interface{}, query string, args ...interface{}) error {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	return Rows(rows, input)
+}
+
+//Rows takes a *sql.Rows and pointer to a slice of struct
+//It will simplify scanning by using the db tags on structs to avoid verbose Scan calls
+func Rows(rows *sql.Rows, input interface{}) error {
+	return mapping.ScanRows(&sqlRows{rows: rows}, input)
+}
@@ -0,0 +1,29 @@
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Oliver-Fish/pgxscan/internal/mapping"
+)
+
+//QueryRow is a wrapper around QueryContext that allows us to avoid the verbose Scan call
+//sql.ErrNoRows is returned when the query returns no rows
+func QueryRow(ctx context.Context, db querier, input interface{}, query string, args ...interface{}) error {
+	rv, dbTagPos, err := mapping.ValidateRowInput(input)
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	err = mapping.ScanRow(&sqlRows{rows: rows}, input, rv, dbTagPos)
+	if err == mapping.ErrNoRows {
+		return sql.ErrNoRows
+	}
+
+	return err
+}
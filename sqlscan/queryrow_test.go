@@ -0,0 +1,124 @@
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestQueryRow(t *testing.T) {
+	type testStruct struct {
+		A string  `db:"a"`
+		B *string `db:"b"`
+		C int     `db:"c"`
+	}
+
+	tests := map[string]struct {
+		query         string
+		expected      testStruct
+		expectedError error
+	}{
+		"All Properties": {
+			query: `
+			SELECT
+				'a' as a,
+				'b' as b,
+				1 as c
+			`,
+			expected: testStruct{
+				A: "a",
+				B: stringPtr("b"),
+				C: 1,
+			},
+		},
+		"Pointer Values set to NULL": {
+			query: `
+			SELECT
+				'a' as a,
+				NULL as b,
+				1 as c
+			`,
+			expected: testStruct{
+				A: "a",
+				C: 1,
+			},
+		},
+		"Missing Column": {
+			query: `
+			SELECT
+				'a' as a
+			`,
+			expected: testStruct{
+				A: "a",
+			},
+			expectedError: ErrQueryColumnsTagsMismtach,
+		},
+		"Extra Column": {
+			query: `
+			SELECT
+				'a' as a,
+				'b' as b,
+				1 as c,
+				'd' as d
+			`,
+			expected: testStruct{
+				A: "a",
+				B: stringPtr("b"),
+				C: 1,
+			},
+			expectedError: &ErrQueryReturnedExtraColumns{
+				ValueType: "*sqlscan.testStruct",
+				Columns:   []string{"d"},
+			},
+		},
+		"No Rows": {
+			query: `
+			SELECT
+				'a' as a
+			WHERE false
+			`,
+			expectedError: sql.ErrNoRows,
+		},
+	}
+
+	ctx := context.Background()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var val testStruct
+			err := QueryRow(ctx, db, &val, tc.query)
+			require.Equal(t, tc.expectedError, err)
+			require.Equal(t, tc.expected, val)
+		})
+	}
+}
+
+func TestQuery(t *testing.T) {
+	type testStruct struct {
+		A int     `db:"a"`
+		B *string `db:"b"`
+	}
+
+	var val []testStruct
+	ctx := context.Background()
+	err := Query(ctx, db, &val, `SELECT g as a, NULL as b FROM generate_series(1, 3) g`)
+	require.NoError(t, err)
+	require.Equal(t, []testStruct{{A: 1}, {A: 2}, {A: 3}}, val)
+}
+
+func TestColumnsError(t *testing.T) {
+	rows, err := db.QueryContext(context.Background(), `SELECT 1 as a`)
+	require.NoError(t, err)
+	rows.Close()
+
+	r := &sqlRows{rows: rows}
+	require.Nil(t, r.Columns())
+	require.False(t, r.Next())
+	require.EqualError(t, r.Scan(), "sql: Rows are closed")
+	require.EqualError(t, r.Err(), "sql: Rows are closed")
+}
@@ -0,0 +1,59 @@
+//Package sqlscan is pgxscan for database/sql
+//It shares its struct mapping with pgxscan so db tags, mismatch errors and NULL handling behave the same
+//
+//A *sql.Row does not expose its column names, single rows are instead read with QueryRow which uses *sql.Rows
+package sqlscan
+
+import (
+	"context"
+	"database/sql"
+)
+
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+//sqlRows adapts *sql.Rows to the rows scanned by the mapping package
+//An error reading the columns closes the rows and is returned by every following Next, Scan and Err
+type sqlRows struct {
+	rows *sql.Rows
+	err  error
+}
+
+func (r *sqlRows) Next() bool {
+	if r.err != nil {
+		return false
+	}
+
+	return r.rows.Next()
+}
+
+func (r *sqlRows) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	return r.rows.Scan(dest...)
+}
+
+func (r *sqlRows) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+
+	return r.rows.Err()
+}
+
+func (r *sqlRows) Close() {
+	r.rows.Close()
+}
+
+func (r *sqlRows) Columns() []string {
+	columns, err := r.rows.Columns()
+	if err != nil {
+		r.err = err
+		r.rows.Close()
+	}
+
+	return columns
+}
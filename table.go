@@ -0,0 +1,88 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Oliver-Fish/pgxscan/internal/mapping"
+)
+
+type tableColumn struct {
+	Name     string `db:"attname"`
+	OID      uint32 `db:"atttypid"`
+	Nullable bool   `db:"nullable"`
+}
+
+//CompareTable compares the columns of table with the db tags of input, a pointer to a struct
+//Columns missing on either side, nullable columns scanned into fields that can not hold NULL
+//and incompatible types are returned as an ErrTableMismatch
+func CompareTable(ctx context.Context, tx Querier, table string, input interface{}) error {
+	rt, err := destinationStruct(input)
+	if err != nil {
+		return err
+	}
+
+	dbTagPos, err := mapping.DBTagPositions(rt)
+	if err != nil {
+		return err
+	}
+
+	var columns []tableColumn
+	err = Query(ctx, tx, &columns, `
+	SELECT
+		a.attname,
+		a.atttypid,
+		NOT a.attnotnull AS nullable
+	FROM pg_attribute a
+	WHERE a.attrelid = $1::regclass
+		AND a.attnum > 0
+		AND NOT a.attisdropped
+	ORDER BY a.attnum
+	`, table)
+	if err != nil {
+		return err
+	}
+
+	mismatch := ErrTableMismatch{
+		Table:     table,
+		ValueType: fmt.Sprintf("%T", input),
+	}
+
+	seen := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		seen[column.Name] = true
+
+		fieldPos, ok := dbTagPos[column.Name]
+		if !ok {
+			mismatch.ExtraColumns = append(mismatch.ExtraColumns, column.Name)
+			continue
+		}
+
+		structField := rt.FieldByIndex(fieldPos)
+		if column.Nullable && !nullableType(structField.Type) {
+			mismatch.NullableColumns = append(mismatch.NullableColumns, column.Name)
+		}
+
+		if !compatibleType(column.OID, structField.Type) {
+			mismatch.TypeMismatches = append(mismatch.TypeMismatches, TypeMismatch{
+				Column:     column.Name,
+				ColumnType: pgTypeName(column.OID),
+				Field:      structField.Name,
+				FieldType:  structField.Type.String(),
+			})
+		}
+	}
+
+	for _, tag := range mapping.SortedDBTags(dbTagPos) {
+		if !seen[tag] {
+			mismatch.MissingColumns = append(mismatch.MissingColumns, tag)
+		}
+	}
+
+	if len(mismatch.MissingColumns) == 0 && len(mismatch.ExtraColumns) == 0 &&
+		len(mismatch.NullableColumns) == 0 && len(mismatch.TypeMismatches) == 0 {
+		return nil
+	}
+
+	return mismatch
+}
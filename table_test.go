@@ -0,0 +1,55 @@
+package pgxscan
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareTable(t *testing.T) {
+	type matching struct {
+		ID        int        `db:"id"`
+		Name      string     `db:"name"`
+		DeletedAt *time.Time `db:"deleted_at"`
+	}
+	type drifted struct {
+		ID        string    `db:"id"`
+		Name      string    `db:"name"`
+		DeletedAt time.Time `db:"deleted_at"`
+		Email     string    `db:"email"`
+	}
+
+	ctx := context.Background()
+	_, err := db.Exec(ctx, `CREATE TABLE compare_table (id int NOT NULL, name text NOT NULL, deleted_at timestamptz, extra text)`)
+	require.NoError(t, err)
+	defer db.Exec(ctx, `DROP TABLE compare_table`)
+
+	err = CompareTable(ctx, db, "compare_table", &matching{})
+	require.Equal(t, ErrTableMismatch{
+		Table:        "compare_table",
+		ValueType:    "*pgxscan.matching",
+		ExtraColumns: []string{"extra"},
+	}, err)
+
+	err = CompareTable(ctx, db, "compare_table", &drifted{})
+	require.Equal(t, ErrTableMismatch{
+		Table:           "compare_table",
+		ValueType:       "*pgxscan.drifted",
+		MissingColumns:  []string{"email"},
+		ExtraColumns:    []string{"extra"},
+		NullableColumns: []string{"deleted_at"},
+		TypeMismatches: []TypeMismatch{
+			{
+				Column:     "id",
+				ColumnType: "int4",
+				Field:      "ID",
+				FieldType:  "string",
+			},
+		},
+	}, err)
+
+	err = CompareTable(ctx, db, "missing_table", &matching{})
+	require.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package pgxscan
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type tagOptionsTestStruct struct {
+	ID       int    `db:"id,pk"`
+	Name     string `db:"name,omitempty"`
+	Password string `db:"password,writeonly"`
+	Status   string `db:"status,readonly"`
+}
+
+func TestTagOptionsQueries(t *testing.T) {
+	ws, err := newWriteStruct(reflect.TypeOf(tagOptionsTestStruct{}))
+	require.NoError(t, err)
+
+	values := []tagOptionsTestStruct{
+		{ID: 1, Name: "a", Password: "secret"},
+		{ID: 2, Password: "secret"},
+	}
+
+	query, args, err := insertQuery(ws, "tag_options", reflect.ValueOf(values), InsertOptions{Returning: true})
+	require.NoError(t, err)
+	require.Equal(t, `INSERT INTO "tag_options" ("id", "name", "password") VALUES ($1, $2, $3), ($4, DEFAULT, $5) RETURNING "id", "name", "status"`, query)
+	require.Equal(t, []interface{}{1, "a", "secret", 2, "secret"}, args)
+
+	query, args, _, err = upsertQuery("tag_options", &values[1], nil, false)
+	require.NoError(t, err)
+	require.Equal(t, `INSERT INTO "tag_options" AS t ("id", "name", "password") VALUES ($1, DEFAULT, $2)`+
+		` ON CONFLICT ("id") DO UPDATE SET "password" = EXCLUDED."password" RETURNING "id", "name", "status"`, query)
+	require.Equal(t, []interface{}{2, "secret"}, args)
+
+	u, err := newUpdate(ws, "tag_options", nil)
+	require.NoError(t, err)
+	query, args, err = u.query(reflect.ValueOf(values))
+	require.NoError(t, err)
+	require.Equal(t, `UPDATE "tag_options" AS t SET "name" = COALESCE(v."name", t."name"), "password" = v."password"`+
+		` FROM (VALUES ($1::bigint, $2::text, $3::text), ($4::bigint, $5::text, $6::text)) AS v ("id", "name", "password")`+
+		` WHERE t."id" = v."id"`, query)
+	require.Equal(t, []interface{}{1, "a", "secret", 2, nil, "secret"}, args)
+
+	columns, err := Columns(&tagOptionsTestStruct{})
+	require.NoError(t, err)
+	require.Equal(t, `"id", "name", "status"`, columns)
+}
+
+func TestTagOptions(t *testing.T) {
+	ctx := context.Background()
+	_, err := db.Exec(ctx, `CREATE TABLE tag_options (id int PRIMARY KEY, name text DEFAULT 'default', password text, status text DEFAULT 'new')`)
+	require.NoError(t, err)
+	defer db.Exec(ctx, `DROP TABLE tag_options`)
+
+	values := []tagOptionsTestStruct{
+		{ID: 1, Name: "a", Password: "secret"},
+		{ID: 2, Password: "secret"},
+	}
+	_, err = InsertMany(ctx, db, "tag_options", values, InsertOptions{Returning: true})
+	require.NoError(t, err)
+	require.Equal(t, []tagOptionsTestStruct{
+		{ID: 1, Name: "a", Password: "secret", Status: "new"},
+		{ID: 2, Name: "default", Password: "secret", Status: "new"},
+	}, values)
+
+	_, err = UpdateMany(ctx, db, "tag_options", []tagOptionsTestStruct{{ID: 1, Password: "changed"}})
+	require.NoError(t, err)
+
+	var val tagOptionsTestStruct
+	err = GetByPK(ctx, db, "tag_options", &val, 1)
+	require.NoError(t, err)
+	require.Equal(t, tagOptionsTestStruct{ID: 1, Name: "a", Status: "new"}, val)
+
+	var password string
+	err = db.QueryRow(ctx, `SELECT password FROM tag_options WHERE id = 1`).Scan(&password)
+	require.NoError(t, err)
+	require.Equal(t, "changed", password)
+}
@@ -0,0 +1,15 @@
+package pgxscan
+
+import "github.com/Oliver-Fish/pgxscan/internal/mapping"
+
+//ParseTag splits a db tag into the column name and the options following it, as in db:"id,pk"
+//It is the parser pgxscan maps structs with, for tools checking or generating code from db tags
+func ParseTag(tag string) (string, []string) {
+	return mapping.ParseTag(tag)
+}
+
+//CheckTagOptions returns an error describing the first invalid option of a db tag,
+//the options are listed in the package documentation
+func CheckTagOptions(options []string) error {
+	return mapping.CheckTagOptions(options)
+}
@@ -0,0 +1,30 @@
+package pgxscan
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTag(t *testing.T) {
+	tests := map[string]struct {
+		tag     string
+		name    string
+		options []string
+		err     error
+	}{
+		"Name":    {tag: "id", name: "id", options: []string{}},
+		"Options": {tag: "id,pk,default=1", name: "id", options: []string{"pk", "default=1"}},
+		"Unknown": {tag: "id,primary", name: "id", options: []string{"primary"}, err: fmt.Errorf("unknown option %q", "primary")},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tag, options := ParseTag(tc.tag)
+			require.Equal(t, tc.name, tag)
+			require.Equal(t, tc.options, options)
+			require.Equal(t, tc.err, CheckTagOptions(options))
+		})
+	}
+}
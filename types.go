@@ -0,0 +1,175 @@
+package pgxscan
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgtype"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	durationType      = reflect.TypeOf(time.Duration(0))
+	rawMessageType    = reflect.TypeOf(json.RawMessage(nil))
+	scannerType       = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	binaryDecoderType = reflect.TypeOf((*pgtype.BinaryDecoder)(nil)).Elem()
+	textDecoderType   = reflect.TypeOf((*pgtype.TextDecoder)(nil)).Elem()
+
+	//connInfo is only read from to look up type names
+	connInfo = pgtype.NewConnInfo()
+)
+
+//compatibleType reports whether a column of the PostgreSQL type oid can be scanned into a field of type rt
+//Types without a rule here, and fields that decode themselves, are assumed to be compatible
+func compatibleType(oid uint32, rt reflect.Type) bool {
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+
+	if rt.Kind() == reflect.Interface {
+		return true
+	}
+
+	ptr := reflect.PtrTo(rt)
+	if ptr.Implements(scannerType) || ptr.Implements(binaryDecoderType) || ptr.Implements(textDecoderType) {
+		return true
+	}
+
+	switch oid {
+	case pgtype.BoolOID:
+		return rt.Kind() == reflect.Bool
+
+	case pgtype.Int2OID, pgtype.Int4OID, pgtype.Int8OID, pgtype.OIDOID,
+		pgtype.Float4OID, pgtype.Float8OID, pgtype.NumericOID:
+		return isNumberKind(rt.Kind())
+
+	case pgtype.TextOID, pgtype.VarcharOID, pgtype.BPCharOID, pgtype.NameOID:
+		return rt.Kind() == reflect.String || isBytesType(rt)
+
+	case pgtype.UUIDOID:
+		return rt.Kind() == reflect.String || (rt.Kind() == reflect.Array && rt.Len() == 16 && rt.Elem().Kind() == reflect.Uint8)
+
+	case pgtype.ByteaOID:
+		return isBytesType(rt)
+
+	case pgtype.DateOID, pgtype.TimestampOID, pgtype.TimestamptzOID:
+		return rt == timeType
+
+	case pgtype.IntervalOID:
+		return rt == durationType
+
+	case pgtype.BoolArrayOID, pgtype.Int2ArrayOID, pgtype.Int4ArrayOID, pgtype.Int8ArrayOID,
+		pgtype.Float4ArrayOID, pgtype.Float8ArrayOID, pgtype.NumericArrayOID, pgtype.TextArrayOID,
+		pgtype.VarcharArrayOID, pgtype.BPCharArrayOID, pgtype.ByteaArrayOID, pgtype.UUIDArrayOID,
+		pgtype.DateArrayOID, pgtype.TimestampArrayOID, pgtype.TimestamptzArrayOID:
+		return (rt.Kind() == reflect.Slice || rt.Kind() == reflect.Array) && !isBytesType(rt)
+	}
+
+	return true
+}
+
+//isBytesType reports whether rt is a byte slice, named ones such as json.RawMessage included
+func isBytesType(rt reflect.Type) bool {
+	return rt.Kind() == reflect.Slice && rt.Elem().Kind() == reflect.Uint8
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return false
+}
+
+//pgTypeName returns the name of the PostgreSQL type oid as known to pgx
+func pgTypeName(oid uint32) string {
+	if dt, ok := connInfo.DataTypeForOID(oid); ok {
+		return dt.Name
+	}
+
+	return fmt.Sprintf("oid %d", oid)
+}
+
+//nullableType reports whether a field of type rt can hold a NULL scanned into it
+func nullableType(rt reflect.Type) bool {
+	switch rt.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		return true
+	}
+
+	ptr := reflect.PtrTo(rt)
+	return ptr.Implements(scannerType) || ptr.Implements(binaryDecoderType) || ptr.Implements(textDecoderType)
+}
+
+//sqlNullTypes are the PostgreSQL types of the database/sql null wrappers
+var sqlNullTypes = map[reflect.Type]string{
+	reflect.TypeOf(sql.NullBool{}):    "boolean",
+	reflect.TypeOf(sql.NullInt16{}):   "smallint",
+	reflect.TypeOf(sql.NullInt32{}):   "integer",
+	reflect.TypeOf(sql.NullInt64{}):   "bigint",
+	reflect.TypeOf(sql.NullFloat64{}): "double precision",
+	reflect.TypeOf(sql.NullString{}):  "text",
+	reflect.TypeOf(sql.NullTime{}):    "timestamptz",
+}
+
+//pgTypeForGo returns the name of the PostgreSQL type a field of type rt is written as
+//It returns false for types without an obvious PostgreSQL type
+func pgTypeForGo(rt reflect.Type) (string, bool) {
+	for rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+
+	if name, ok := sqlNullTypes[rt]; ok {
+		return name, true
+	}
+
+	switch rt {
+	case timeType:
+		return "timestamptz", true
+	case durationType:
+		return "interval", true
+	case rawMessageType:
+		return "jsonb", true
+	}
+
+	if isBytesType(rt) {
+		return "bytea", true
+	}
+
+	switch rt.Kind() {
+	case reflect.Bool:
+		return "boolean", true
+	case reflect.Int8, reflect.Int16, reflect.Uint8:
+		return "smallint", true
+	case reflect.Int32, reflect.Uint16:
+		return "integer", true
+	case reflect.Int, reflect.Int64, reflect.Uint32:
+		return "bigint", true
+	case reflect.Uint, reflect.Uint64:
+		return "numeric", true
+	case reflect.Float32:
+		return "real", true
+	case reflect.Float64:
+		return "double precision", true
+	case reflect.String:
+		return "text", true
+	case reflect.Array:
+		if rt.Len() == 16 && rt.Elem().Kind() == reflect.Uint8 {
+			return "uuid", true
+		}
+	case reflect.Slice:
+		elem, ok := pgTypeForGo(rt.Elem())
+		if ok && !strings.HasSuffix(elem, "[]") {
+			return elem + "[]", true
+		}
+	}
+
+	return "", false
+}
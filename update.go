@@ -0,0 +1,247 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Oliver-Fish/pgxscan/internal/mapping"
+	"github.com/jackc/pgx/v4"
+)
+
+//UpdateMany updates the rows of table matching values on keyColumns and returns the number of rows updated
+//The key columns default to the fields tagged pk, every other writable db tag of T, a struct, is set
+//except for zero values of fields tagged omitempty which keep the stored value
+//Each statement is a single UPDATE ... FROM (VALUES ...) with the values cast to the PostgreSQL type of their field,
+//values are split over as many statements as needed to stay under the PostgreSQL bind parameter limit
+//The statements run one after another on q, pass a transaction for the update to be all or nothing
+//A field tagged version only lets rows with the same version be updated and increments it in the table and in values,
+//ErrStaleVersion is returned if a statement updates fewer rows than it was given,
+//the values whose rows were updated before it is returned still have their version incremented
+func UpdateMany[T any](ctx context.Context, q Querier, table string, values []T, keyColumns ...string) (int64, error) {
+	rt := reflect.TypeOf((*T)(nil)).Elem()
+	if rt.Kind() != reflect.Struct {
+		return 0, fmt.Errorf("type %s is not a struct", rt.String())
+	}
+
+	ws, err := newWriteStruct(rt)
+	if err != nil {
+		return 0, err
+	}
+
+	u, err := newUpdate(ws, table, keyColumns)
+	if err != nil {
+		return 0, err
+	}
+
+	chunkSize := maxParameters / len(u.columns)
+	rv := reflect.ValueOf(values)
+
+	var updated int64
+	for start := 0; start < len(values); start += chunkSize {
+		end := start + chunkSize
+		if end > len(values) {
+			end = len(values)
+		}
+
+		query, args, err := u.query(rv.Slice(start, end))
+		if err != nil {
+			return updated, err
+		}
+
+		if ws.version == "" {
+			count, err := exec(ctx, q, query, args...)
+			updated += count
+			if err != nil {
+				return updated, err
+			}
+			continue
+		}
+
+		ordinals, count, err := execReturningOrdinals(ctx, q, query, args...)
+		updated += count
+		if err != nil {
+			return updated, err
+		}
+
+		//Only the rows that were updated had their version incremented in the table
+		for _, ordinal := range ordinals {
+			ws.bumpVersion(rv.Index(start + ordinal))
+		}
+
+		if count != int64(end-start) {
+			return updated, ErrStaleVersion{
+				Table:     table,
+				ValueType: reflect.PtrTo(rt).String(),
+				Expected:  int64(end - start),
+				Updated:   count,
+			}
+		}
+	}
+
+	return updated, nil
+}
+
+//ordinalColumn is the VALUES column holding the position of each value in its chunk,
+//returned by versioned updates to find the values whose rows were updated
+const ordinalColumn = "pgxscan_ordinal"
+
+//execReturningOrdinals runs the versioned UPDATE statement query and returns the distinct ordinals it returned
+//and the number of rows it updated
+func execReturningOrdinals(ctx context.Context, q Querier, query string, args ...interface{}) ([]int, int64, error) {
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var ordinals []int
+	seen := make(map[int]bool)
+	for rows.Next() {
+		var ordinal int
+		err = rows.Scan(&ordinal)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !seen[ordinal] {
+			seen[ordinal] = true
+			ordinals = append(ordinals, ordinal)
+		}
+	}
+	rows.Close()
+
+	err = rows.Err()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return ordinals, rows.CommandTag().RowsAffected(), nil
+}
+
+//update builds the UPDATE statements of UpdateMany
+type update struct {
+	ws    *writeStruct
+	table string
+	keys  []string
+	set   []string
+	//columns are the keys followed by the set columns and the version, in the order of the VALUES list
+	columns []string
+	casts   []string
+}
+
+func newUpdate(ws *writeStruct, table string, keyColumns []string) (*update, error) {
+	if len(keyColumns) == 0 {
+		keyColumns = ws.pk
+	}
+	if len(keyColumns) == 0 {
+		return nil, fmt.Errorf("update of %s needs key columns or fields tagged pk", ws.rt.String())
+	}
+	for _, column := range keyColumns {
+		if _, ok := ws.dbTagPos[column]; !ok {
+			return nil, fmt.Errorf("key column %s is not a db tag of %s", column, ws.rt.String())
+		}
+	}
+
+	u := &update{
+		ws:    ws,
+		table: table,
+		keys:  keyColumns,
+	}
+	for _, column := range ws.writable {
+		if !contains(keyColumns, column) && column != ws.version {
+			u.set = append(u.set, column)
+		}
+	}
+	if len(u.set) == 0 {
+		return nil, fmt.Errorf("update of %s has no columns to set", ws.rt.String())
+	}
+
+	u.columns = append(append([]string{}, u.keys...), u.set...)
+	if ws.version != "" && !contains(u.keys, ws.version) {
+		u.columns = append(u.columns, ws.version)
+	}
+	u.casts = make([]string, len(u.columns))
+	for i, column := range u.columns {
+		field := ws.rt.FieldByIndex(ws.dbTagPos[column])
+		pgType, ok := pgTypeForGo(field.Type)
+		if !ok {
+			return nil, fmt.Errorf("no PostgreSQL type to cast field %s of type %s to", field.Name, field.Type.String())
+		}
+		u.casts[i] = pgType
+	}
+
+	return u, nil
+}
+
+//query returns the UPDATE statement and arguments of the structs in the slice chunk
+func (u *update) query(chunk reflect.Value) (string, []interface{}, error) {
+	args := make([]interface{}, 0, chunk.Len()*len(u.columns))
+	rowValues := make([]string, chunk.Len())
+	for i := 0; i < chunk.Len(); i++ {
+		rv := chunk.Index(i)
+		values, err := u.ws.values(rv, u.columns)
+		if err != nil {
+			return "", nil, err
+		}
+
+		//Omitted set columns are passed as NULL so the stored value is kept
+		for ii, column := range u.columns {
+			if ii >= len(u.keys) && contains(u.set, column) && u.ws.omitted(rv, column) {
+				values[ii] = nil
+			}
+		}
+
+		params := make([]string, len(values))
+		for ii := range values {
+			params[ii] = fmt.Sprintf("$%d::%s", len(args)+ii+1, u.casts[ii])
+		}
+		if u.ws.version != "" {
+			params = append(params, fmt.Sprint(i))
+		}
+		rowValues[i] = "(" + strings.Join(params, ", ") + ")"
+		args = append(args, values...)
+	}
+
+	set := make([]string, len(u.set))
+	for i, column := range u.set {
+		quoted := pgx.Identifier{column}.Sanitize()
+		if mapping.HasTagOption(u.ws.rt.FieldByIndex(u.ws.dbTagPos[column]), "omitempty") {
+			set[i] = quoted + " = COALESCE(v." + quoted + ", t." + quoted + ")"
+			continue
+		}
+		set[i] = quoted + " = v." + quoted
+	}
+
+	where := make([]string, len(u.keys))
+	for i, column := range u.keys {
+		quoted := pgx.Identifier{column}.Sanitize()
+		where[i] = "t." + quoted + " = v." + quoted
+	}
+
+	if u.ws.version != "" {
+		quoted := pgx.Identifier{u.ws.version}.Sanitize()
+		set = append(set, quoted+" = t."+quoted+" + 1")
+		if !contains(u.keys, u.ws.version) {
+			where = append(where, "t."+quoted+" = v."+quoted)
+		}
+	}
+
+	columns := u.columns
+	if u.ws.version != "" {
+		columns = append(columns[:len(columns):len(columns)], ordinalColumn)
+	}
+
+	query := fmt.Sprintf("UPDATE %s AS t SET %s FROM (VALUES %s) AS v (%s) WHERE %s",
+		tableIdentifier(u.table),
+		strings.Join(set, ", "),
+		strings.Join(rowValues, ", "),
+		quoteColumns(columns, ""),
+		strings.Join(where, " AND "),
+	)
+	if u.ws.version != "" {
+		query += " RETURNING v." + pgx.Identifier{ordinalColumn}.Sanitize()
+	}
+
+	return query, args, nil
+}
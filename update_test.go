@@ -0,0 +1,143 @@
+package pgxscan
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type updateTestStruct struct {
+	ID        int        `db:"id,pk"`
+	Name      *string    `db:"name"`
+	Tags      []string   `db:"tags"`
+	UpdatedAt time.Time  `db:"updated_at"`
+	CreatedAt *time.Time `db:"created_at,readonly"`
+}
+
+func TestUpdateQuery(t *testing.T) {
+	ws, err := newWriteStruct(reflect.TypeOf(updateTestStruct{}))
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		keyColumns []string
+		query      string
+		err        error
+	}{
+		"PrimaryKey": {
+			query: `UPDATE "update_many" AS t SET "name" = v."name", "tags" = v."tags", "updated_at" = v."updated_at"` +
+				` FROM (VALUES ($1::bigint, $2::text, $3::text[], $4::timestamptz), ($5::bigint, $6::text, $7::text[], $8::timestamptz))` +
+				` AS v ("id", "name", "tags", "updated_at") WHERE t."id" = v."id"`,
+		},
+		"KeyColumns": {
+			keyColumns: []string{"id", "name"},
+			query: `UPDATE "update_many" AS t SET "tags" = v."tags", "updated_at" = v."updated_at"` +
+				` FROM (VALUES ($1::bigint, $2::text, $3::text[], $4::timestamptz), ($5::bigint, $6::text, $7::text[], $8::timestamptz))` +
+				` AS v ("id", "name", "tags", "updated_at") WHERE t."id" = v."id" AND t."name" = v."name"`,
+		},
+		"UnknownKeyColumn": {
+			keyColumns: []string{"missing"},
+			err:        fmt.Errorf("key column missing is not a db tag of pgxscan.updateTestStruct"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			u, err := newUpdate(ws, "update_many", tc.keyColumns)
+			require.Equal(t, tc.err, err)
+			if err != nil {
+				return
+			}
+
+			query, args, err := u.query(reflect.ValueOf([]updateTestStruct{{ID: 1}, {ID: 2}}))
+			require.NoError(t, err)
+			require.Equal(t, tc.query, query)
+			require.Len(t, args, 8)
+		})
+	}
+}
+
+func TestUpdateQueryBytes(t *testing.T) {
+	type blob []byte
+	type testStruct struct {
+		ID   int             `db:"id,pk"`
+		Data json.RawMessage `db:"data"`
+		File blob            `db:"file"`
+	}
+
+	ws, err := newWriteStruct(reflect.TypeOf(testStruct{}))
+	require.NoError(t, err)
+	u, err := newUpdate(ws, "files", nil)
+	require.NoError(t, err)
+
+	query, _, err := u.query(reflect.ValueOf([]testStruct{{ID: 1}}))
+	require.NoError(t, err)
+	require.Equal(t, `UPDATE "files" AS t SET "data" = v."data", "file" = v."file"`+
+		` FROM (VALUES ($1::bigint, $2::jsonb, $3::bytea)) AS v ("id", "data", "file") WHERE t."id" = v."id"`, query)
+}
+
+func TestPGTypeForGo(t *testing.T) {
+	type blob []byte
+
+	tests := map[string]struct {
+		value    interface{}
+		expected string
+		ok       bool
+	}{
+		"Int":        {value: 1, expected: "bigint", ok: true},
+		"Int32":      {value: int32(1), expected: "integer", ok: true},
+		"StringPtr":  {value: stringPtr(""), expected: "text", ok: true},
+		"Bytes":      {value: []byte{}, expected: "bytea", ok: true},
+		"NamedBytes": {value: blob{}, expected: "bytea", ok: true},
+		"RawMessage": {value: json.RawMessage{}, expected: "jsonb", ok: true},
+		"Time":       {value: time.Time{}, expected: "timestamptz", ok: true},
+		"IntSlice":   {value: []int{}, expected: "bigint[]", ok: true},
+		"UUID":       {value: [16]byte{}, expected: "uuid", ok: true},
+		"NullString": {value: sql.NullString{}, expected: "text", ok: true},
+		"Nested":     {value: [][]int{}},
+		"Map":        {value: map[string]int{}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			pgType, ok := pgTypeForGo(reflect.TypeOf(tc.value))
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.expected, pgType)
+		})
+	}
+}
+
+func TestUpdateMany(t *testing.T) {
+	ctx := context.Background()
+	_, err := db.Exec(ctx, `CREATE TABLE update_many (id int PRIMARY KEY, name text, tags text[], updated_at timestamptz, created_at timestamptz)`)
+	require.NoError(t, err)
+	defer db.Exec(ctx, `DROP TABLE update_many`)
+
+	_, err = db.Exec(ctx, `INSERT INTO update_many SELECT g, 'old', '{}', now(), now() FROM generate_series(1, 3) g`)
+	require.NoError(t, err)
+
+	updatedAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	count, err := UpdateMany(ctx, db, "update_many", []updateTestStruct{
+		{ID: 1, Name: stringPtr("a"), Tags: []string{"x"}, UpdatedAt: updatedAt},
+		{ID: 2, Name: nil, UpdatedAt: updatedAt},
+		{ID: 4, Name: stringPtr("missing"), UpdatedAt: updatedAt},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	var rows []updateTestStruct
+	err = Query(ctx, db, &rows, `SELECT id, name, tags, updated_at, created_at FROM update_many ORDER BY id`)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	require.Equal(t, stringPtr("a"), rows[0].Name)
+	require.Equal(t, []string{"x"}, rows[0].Tags)
+	require.True(t, updatedAt.Equal(rows[0].UpdatedAt))
+	require.NotNil(t, rows[0].CreatedAt)
+	require.Nil(t, rows[1].Name)
+	require.Equal(t, stringPtr("old"), rows[2].Name)
+}
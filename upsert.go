@@ -0,0 +1,136 @@
+package pgxscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+)
+
+//Upsert inserts value, a pointer to a struct, into table or updates the row it conflicts with on conflictColumns
+//The conflict columns default to the fields tagged pk, every other writable column is set from the inserted row
+//Fields tagged readonly, as in db:"created_at,readonly", are never written but like every db tag not tagged writeonly
+//are read back into value from the RETURNING clause so database defaults and triggers are reflected
+//Zero values of fields tagged omitempty are inserted as DEFAULT and leave the conflicting row's value unchanged
+//A field tagged version, as in db:"version,version", only lets the conflicting row be updated
+//if its version still equals the one in value and increments it, ErrStaleVersion is returned otherwise
+func Upsert(ctx context.Context, q Querier, table string, value interface{}, conflictColumns ...string) error {
+	query, args, ws, err := upsertQuery(table, value, conflictColumns, false)
+	if err != nil {
+		return err
+	}
+
+	err = QueryRow(ctx, q, value, query, args...)
+	if errors.Is(err, pgx.ErrNoRows) && ws.version != "" {
+		//DO UPDATE returns the conflicting row unless its version didn't match
+		return ErrStaleVersion{
+			Table:     table,
+			ValueType: fmt.Sprintf("%T", value),
+			Expected:  1,
+		}
+	}
+
+	return err
+}
+
+//UpsertDoNothing inserts value, a pointer to a struct, into table unless it conflicts with an existing row
+//conflictColumns may be empty to skip on any conflict, inserted is false when a conflicting row was found
+//The inserted row is read back into value as with Upsert, value is left untouched on conflict
+func UpsertDoNothing(ctx context.Context, q Querier, table string, value interface{}, conflictColumns ...string) (bool, error) {
+	query, args, _, err := upsertQuery(table, value, conflictColumns, true)
+	if err != nil {
+		return false, err
+	}
+
+	err = QueryRow(ctx, q, value, query, args...)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+//upsertQuery returns the statement and arguments of Upsert and UpsertDoNothing and the db tag metadata of value
+func upsertQuery(table string, value interface{}, conflictColumns []string, doNothing bool) (string, []interface{}, *writeStruct, error) {
+	rv, err := structValue(value)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	ws, err := newWriteStruct(rv.Type())
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	if len(conflictColumns) == 0 {
+		conflictColumns = ws.pk
+	}
+	for _, column := range conflictColumns {
+		if _, ok := ws.dbTagPos[column]; !ok {
+			return "", nil, nil, fmt.Errorf("conflict column %s is not a db tag of %s", column, ws.rt.String())
+		}
+	}
+
+	entries, args, err := ws.insertValues(rv, ws.writable, 1)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	var query strings.Builder
+	if len(ws.writable) == 0 {
+		fmt.Fprintf(&query, "INSERT INTO %s AS t DEFAULT VALUES ON CONFLICT", tableIdentifier(table))
+	} else {
+		fmt.Fprintf(&query, "INSERT INTO %s AS t (%s) VALUES (%s) ON CONFLICT",
+			tableIdentifier(table),
+			quoteColumns(ws.writable, ""),
+			strings.Join(entries, ", "),
+		)
+	}
+	if len(conflictColumns) > 0 {
+		fmt.Fprintf(&query, " (%s)", quoteColumns(conflictColumns, ""))
+	}
+
+	if doNothing {
+		query.WriteString(" DO NOTHING")
+	} else {
+		if len(conflictColumns) == 0 {
+			return "", nil, nil, fmt.Errorf("upsert of %s needs conflict columns or fields tagged pk", ws.rt.String())
+		}
+
+		var set []string
+		for _, column := range ws.writable {
+			//Omitted columns keep the value of the existing row
+			if contains(conflictColumns, column) || contains(ws.pk, column) || column == ws.version || ws.omitted(rv, column) {
+				continue
+			}
+			quoted := pgx.Identifier{column}.Sanitize()
+			set = append(set, quoted+" = EXCLUDED."+quoted)
+		}
+
+		if ws.version != "" {
+			quoted := pgx.Identifier{ws.version}.Sanitize()
+			set = append(set, quoted+" = t."+quoted+" + 1")
+		}
+
+		//Without columns to update the conflict target is set to itself so the existing row is still returned
+		if len(set) == 0 {
+			quoted := pgx.Identifier{conflictColumns[0]}.Sanitize()
+			set = append(set, quoted+" = EXCLUDED."+quoted)
+		}
+
+		fmt.Fprintf(&query, " DO UPDATE SET %s", strings.Join(set, ", "))
+		if ws.version != "" {
+			quoted := pgx.Identifier{ws.version}.Sanitize()
+			fmt.Fprintf(&query, " WHERE t.%s = EXCLUDED.%s", quoted, quoted)
+		}
+	}
+
+	fmt.Fprintf(&query, " RETURNING %s", quoteColumns(ws.readable, ""))
+
+	return query.String(), args, ws, nil
+}
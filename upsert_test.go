@@ -0,0 +1,92 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type upsertTestStruct struct {
+	ID        int       `db:"id,pk"`
+	Name      string    `db:"name"`
+	Count     int       `db:"count"`
+	Ignored   string    `db:"-"`
+	CreatedAt time.Time `db:"created_at,readonly"`
+}
+
+func TestUpsertQuery(t *testing.T) {
+	tests := map[string]struct {
+		conflictColumns []string
+		doNothing       bool
+		query           string
+		err             error
+	}{
+		"PrimaryKey": {
+			query: `INSERT INTO "public"."upsert" AS t ("id", "name", "count") VALUES ($1, $2, $3)` +
+				` ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name", "count" = EXCLUDED."count"` +
+				` RETURNING "id", "name", "count", "created_at"`,
+		},
+		"ConflictColumns": {
+			conflictColumns: []string{"name"},
+			query: `INSERT INTO "public"."upsert" AS t ("id", "name", "count") VALUES ($1, $2, $3)` +
+				` ON CONFLICT ("name") DO UPDATE SET "count" = EXCLUDED."count"` +
+				` RETURNING "id", "name", "count", "created_at"`,
+		},
+		"DoNothing": {
+			doNothing: true,
+			query: `INSERT INTO "public"."upsert" AS t ("id", "name", "count") VALUES ($1, $2, $3)` +
+				` ON CONFLICT ("id") DO NOTHING` +
+				` RETURNING "id", "name", "count", "created_at"`,
+		},
+		"UnknownConflictColumn": {
+			conflictColumns: []string{"missing"},
+			err:             fmt.Errorf("conflict column missing is not a db tag of pgxscan.upsertTestStruct"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			value := upsertTestStruct{ID: 1, Name: "a", Count: 2}
+			query, args, _, err := upsertQuery("public.upsert", &value, tc.conflictColumns, tc.doNothing)
+			require.Equal(t, tc.err, err)
+			require.Equal(t, tc.query, query)
+			if tc.err == nil {
+				require.Equal(t, []interface{}{1, "a", 2}, args)
+			}
+		})
+	}
+}
+
+func TestUpsert(t *testing.T) {
+	ctx := context.Background()
+	_, err := db.Exec(ctx, `CREATE TABLE upsert (id int PRIMARY KEY, name text, count int, created_at timestamptz DEFAULT now())`)
+	require.NoError(t, err)
+	defer db.Exec(ctx, `DROP TABLE upsert`)
+
+	value := upsertTestStruct{ID: 1, Name: "a", Count: 1}
+	err = Upsert(ctx, db, "upsert", &value)
+	require.NoError(t, err)
+	require.False(t, value.CreatedAt.IsZero())
+	createdAt := value.CreatedAt
+
+	value = upsertTestStruct{ID: 1, Name: "b", Count: 2}
+	err = Upsert(ctx, db, "upsert", &value)
+	require.NoError(t, err)
+	require.Equal(t, "b", value.Name)
+	require.True(t, createdAt.Equal(value.CreatedAt))
+
+	value = upsertTestStruct{ID: 1, Name: "c", Count: 3}
+	inserted, err := UpsertDoNothing(ctx, db, "upsert", &value)
+	require.NoError(t, err)
+	require.False(t, inserted)
+	require.Equal(t, upsertTestStruct{ID: 1, Name: "c", Count: 3}, value)
+
+	value = upsertTestStruct{ID: 2, Name: "d", Count: 4}
+	inserted, err = UpsertDoNothing(ctx, db, "upsert", &value)
+	require.NoError(t, err)
+	require.True(t, inserted)
+	require.False(t, value.CreatedAt.IsZero())
+}
@@ -0,0 +1,101 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type versionTestStruct struct {
+	ID      int    `db:"id,pk"`
+	Name    string `db:"name"`
+	Version int    `db:"version,version"`
+}
+
+func TestVersionQueries(t *testing.T) {
+	value := versionTestStruct{ID: 1, Name: "a", Version: 3}
+	query, _, _, err := upsertQuery("versioned", &value, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, `INSERT INTO "versioned" AS t ("id", "name", "version") VALUES ($1, $2, $3)`+
+		` ON CONFLICT ("id") DO UPDATE SET "name" = EXCLUDED."name", "version" = t."version" + 1`+
+		` WHERE t."version" = EXCLUDED."version" RETURNING "id", "name", "version"`, query)
+
+	ws, err := newWriteStruct(reflect.TypeOf(versionTestStruct{}))
+	require.NoError(t, err)
+	u, err := newUpdate(ws, "versioned", nil)
+	require.NoError(t, err)
+	query, args, err := u.query(reflect.ValueOf([]versionTestStruct{value}))
+	require.NoError(t, err)
+	require.Equal(t, `UPDATE "versioned" AS t SET "name" = v."name", "version" = t."version" + 1`+
+		` FROM (VALUES ($1::bigint, $2::text, $3::bigint, 0)) AS v ("id", "name", "version", "pgxscan_ordinal")`+
+		` WHERE t."id" = v."id" AND t."version" = v."version" RETURNING v."pgxscan_ordinal"`, query)
+	require.Equal(t, []interface{}{1, "a", 3}, args)
+
+	_, err = newWriteStruct(reflect.TypeOf(struct {
+		Version string `db:"version,version"`
+	}{}))
+	require.Equal(t, fmt.Errorf("version field Version of struct struct { Version string \"db:\\\"version,version\\\"\" } is not an integer"), err)
+}
+
+func TestVersion(t *testing.T) {
+	ctx := context.Background()
+	_, err := db.Exec(ctx, `CREATE TABLE versioned (id int PRIMARY KEY, name text, version int)`)
+	require.NoError(t, err)
+	defer db.Exec(ctx, `DROP TABLE versioned`)
+
+	value := versionTestStruct{ID: 1, Name: "a", Version: 1}
+	err = Upsert(ctx, db, "versioned", &value)
+	require.NoError(t, err)
+	require.Equal(t, 1, value.Version)
+
+	value.Name = "b"
+	err = Upsert(ctx, db, "versioned", &value)
+	require.NoError(t, err)
+	require.Equal(t, versionTestStruct{ID: 1, Name: "b", Version: 2}, value)
+
+	stale := versionTestStruct{ID: 1, Name: "stale", Version: 1}
+	err = Upsert(ctx, db, "versioned", &stale)
+	require.Equal(t, ErrStaleVersion{
+		Table:     "versioned",
+		ValueType: "*pgxscan.versionTestStruct",
+		Expected:  1,
+	}, err)
+
+	values := []versionTestStruct{value}
+	values[0].Name = "c"
+	count, err := UpdateMany(ctx, db, "versioned", values)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+	require.Equal(t, 3, values[0].Version)
+
+	count, err = UpdateMany(ctx, db, "versioned", []versionTestStruct{stale})
+	require.Equal(t, ErrStaleVersion{
+		Table:     "versioned",
+		ValueType: "*pgxscan.versionTestStruct",
+		Expected:  1,
+	}, err)
+	require.Equal(t, int64(0), count)
+
+	var current versionTestStruct
+	err = GetByPK(ctx, db, "versioned", &current, 1)
+	require.NoError(t, err)
+	require.Equal(t, versionTestStruct{ID: 1, Name: "c", Version: 3}, current)
+
+	_, err = db.Exec(ctx, `INSERT INTO versioned VALUES (2, 'a', 1)`)
+	require.NoError(t, err)
+
+	//Only the value whose row was updated has its version incremented
+	values = []versionTestStruct{{ID: 1, Name: "d", Version: 1}, {ID: 2, Name: "d", Version: 1}}
+	count, err = UpdateMany(ctx, db, "versioned", values)
+	require.Equal(t, ErrStaleVersion{
+		Table:     "versioned",
+		ValueType: "*pgxscan.versionTestStruct",
+		Expected:  2,
+		Updated:   1,
+	}, err)
+	require.Equal(t, int64(1), count)
+	require.Equal(t, []versionTestStruct{{ID: 1, Name: "d", Version: 1}, {ID: 2, Name: "d", Version: 2}}, values)
+}
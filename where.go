@@ -0,0 +1,145 @@
+package pgxscan
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Oliver-Fish/pgxscan/internal/mapping"
+	"github.com/jackc/pgx/v4"
+)
+
+//Range filters a column on bounds, a nil bound is not applied and both bounds are included
+type Range[T any] struct {
+	From *T
+	To   *T
+}
+
+func (r Range[T]) bounds() (interface{}, interface{}) {
+	var from, to interface{}
+	if r.From != nil {
+		from = *r.From
+	}
+	if r.To != nil {
+		to = *r.To
+	}
+
+	return from, to
+}
+
+type rangeBounds interface {
+	bounds() (interface{}, interface{})
+}
+
+var rangeBoundsType = reflect.TypeOf((*rangeBounds)(nil)).Elem()
+
+//Where returns a condition and its arguments built from filter, a struct or pointer to a struct of db tagged fields
+//Nil pointers and slices are skipped as are zero values of fields tagged omitempty, every other field becomes
+//a predicate comparing its column with the op tag option, as in db:"name,op=ilike", which defaults to =
+//
+//	=, <>, <, <=, >, >=  compare the column with the value
+//	like, ilike          match the column against the value as a pattern
+//	in                   match the column against any element of a slice, an empty slice matches nothing
+//
+//Range fields filter the column between their bounds and can't have an op tag option
+//The predicates are joined with AND, the condition is TRUE when there are none so it can always follow WHERE or AND
+//Placeholders start at $1, further arguments of the query follow the returned ones
+func Where(filter interface{}) (string, []interface{}, error) {
+	rv := reflect.ValueOf(filter)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return "", nil, fmt.Errorf("filter value is a nil pointer")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return "", nil, fmt.Errorf("filter value is not a struct or pointer to a struct")
+	}
+
+	rt := rv.Type()
+	dbTagPos, err := mapping.DBTagPositions(rt)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var conditions []string
+	var args []interface{}
+	placeholder := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	for _, column := range mapping.SortedDBTags(dbTagPos) {
+		field := rt.FieldByIndex(dbTagPos[column])
+
+		//op values are checked with the other tag options, a Range is checked before its value is read
+		//so a filter type is rejected whatever values it holds
+		op, hasOp := mapping.TagOptionValue(field, "op")
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		isRange := fieldType.Implements(rangeBoundsType)
+		if hasOp && isRange {
+			return "", nil, fmt.Errorf("range field %s of struct %s can't have the op option", field.Name, rt.String())
+		}
+		if !hasOp {
+			op = "="
+		}
+		operator, _ := mapping.WhereOperator(op)
+
+		fieldVal, ok := mapping.FieldByIndex(rv, dbTagPos[column])
+		if !ok {
+			continue
+		}
+		if !fieldVal.CanInterface() {
+			return "", nil, ErrUnexportedProperty{
+				PropertyName: field.Name,
+			}
+		}
+
+		if mapping.HasTagOption(field, "omitempty") && fieldVal.IsZero() {
+			continue
+		}
+		if fieldVal.Kind() == reflect.Ptr {
+			if fieldVal.IsNil() {
+				continue
+			}
+			fieldVal = fieldVal.Elem()
+		}
+		if fieldVal.Kind() == reflect.Slice && fieldVal.IsNil() {
+			continue
+		}
+
+		quoted := pgx.Identifier{column}.Sanitize()
+		value := fieldVal.Interface()
+
+		if isRange {
+			from, to := value.(rangeBounds).bounds()
+			if from != nil {
+				conditions = append(conditions, quoted+" >= "+placeholder(from))
+			}
+			if to != nil {
+				conditions = append(conditions, quoted+" <= "+placeholder(to))
+			}
+			continue
+		}
+
+		if operator == "IN" {
+			if fieldVal.Kind() != reflect.Slice && fieldVal.Kind() != reflect.Array {
+				return "", nil, fmt.Errorf("field %s of struct %s with operator in is not a slice", field.Name, rt.String())
+			}
+			//An array parameter keeps the statement the same for any number of values
+			conditions = append(conditions, quoted+" = ANY("+placeholder(value)+")")
+			continue
+		}
+
+		conditions = append(conditions, quoted+" "+operator+" "+placeholder(value))
+	}
+
+	if len(conditions) == 0 {
+		return "TRUE", nil, nil
+	}
+
+	return strings.Join(conditions, " AND "), args, nil
+}
@@ -0,0 +1,104 @@
+package pgxscan
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type whereTestFilter struct {
+	Name    *string           `db:"name,op=ilike"`
+	IDs     []int             `db:"id,op=in"`
+	Age     Range[int]        `db:"age"`
+	Status  string            `db:"status,omitempty"`
+	Created *Range[time.Time] `db:"created_at"`
+	MinRank *int              `db:"rank,op=>="`
+}
+
+func TestWhere(t *testing.T) {
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		filter    interface{}
+		condition string
+		args      []interface{}
+		err       error
+	}{
+		"Empty": {
+			filter:    whereTestFilter{},
+			condition: "TRUE",
+		},
+		"Pointer": {
+			filter:    &whereTestFilter{Name: stringPtr("a%")},
+			condition: `"name" ILIKE $1`,
+			args:      []interface{}{"a%"},
+		},
+		"AllFields": {
+			filter: whereTestFilter{
+				Name:    stringPtr("a%"),
+				IDs:     []int{1, 2},
+				Age:     Range[int]{From: intPtr(18)},
+				Status:  "active",
+				Created: &Range[time.Time]{From: &from, To: &from},
+				MinRank: intPtr(3),
+			},
+			condition: `"name" ILIKE $1 AND "id" = ANY($2) AND "age" >= $3 AND "status" = $4` +
+				` AND "created_at" >= $5 AND "created_at" <= $6 AND "rank" >= $7`,
+			args: []interface{}{"a%", []int{1, 2}, 18, "active", from, from, 3},
+		},
+		"UnknownOperator": {
+			filter: struct {
+				A *int `db:"a,op=~"`
+			}{},
+			err: ErrInvalidTagOption{
+				Struct: "struct { A *int \"db:\\\"a,op=~\\\"\" }",
+				Field:  "A",
+				Err:    fmt.Errorf("option %q has unknown operator %q", "op", "~"),
+			},
+		},
+		"RangeOperator": {
+			filter: struct {
+				A *Range[int] `db:"a,op=<"`
+			}{},
+			err: fmt.Errorf("range field A of struct struct { A *pgxscan.Range[int] \"db:\\\"a,op=<\\\"\" } can't have the op option"),
+		},
+		"InNotSlice": {
+			filter: struct {
+				A *int `db:"a,op=in"`
+			}{A: intPtr(1)},
+			err: fmt.Errorf("field A of struct struct { A *int \"db:\\\"a,op=in\\\"\" } with operator in is not a slice"),
+		},
+		"NotStruct": {
+			filter: 1,
+			err:    fmt.Errorf("filter value is not a struct or pointer to a struct"),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			condition, args, err := Where(tc.filter)
+			require.Equal(t, tc.err, err)
+			require.Equal(t, tc.condition, condition)
+			require.Equal(t, tc.args, args)
+		})
+	}
+}
+
+func TestWhereQuery(t *testing.T) {
+	type row struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+
+	condition, args, err := Where(whereTestFilter{IDs: []int{1, 3}})
+	require.NoError(t, err)
+
+	var rows []row
+	ctx := context.Background()
+	err = Query(ctx, db, &rows, `SELECT id, name FROM (VALUES (1, 'a'), (2, 'b'), (3, 'c')) AS v (id, name) WHERE `+condition+` ORDER BY id`, args...)
+	require.NoError(t, err)
+	require.Equal(t, []row{{ID: 1, Name: "a"}, {ID: 3, Name: "c"}}, rows)
+}
@@ -0,0 +1,178 @@
+package pgxscan
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Oliver-Fish/pgxscan/internal/mapping"
+	"github.com/jackc/pgx/v4"
+)
+
+//writeStruct is the db tag metadata of a struct type used to build write statements
+type writeStruct struct {
+	rt       reflect.Type
+	dbTagPos map[string][]int
+	//readable are the db tags in field order without the fields tagged writeonly,
+	//writable are the db tags in field order without the fields tagged readonly
+	readable []string
+	writable []string
+	pk       []string
+	//version is the db tag of the field tagged version, empty if there is none
+	version string
+}
+
+func newWriteStruct(rt reflect.Type) (*writeStruct, error) {
+	dbTagPos, err := mapping.DBTagPositions(rt)
+	if err != nil {
+		return nil, err
+	}
+
+	ws := &writeStruct{
+		rt:       rt,
+		dbTagPos: dbTagPos,
+		pk:       mapping.TagsWithOption(rt, dbTagPos, "pk"),
+	}
+
+	for _, column := range mapping.SortedDBTags(dbTagPos) {
+		field := rt.FieldByIndex(dbTagPos[column])
+		if !mapping.HasTagOption(field, "writeonly") {
+			ws.readable = append(ws.readable, column)
+		}
+		if !mapping.HasTagOption(field, "readonly") {
+			ws.writable = append(ws.writable, column)
+		}
+	}
+
+	versions := mapping.TagsWithOption(rt, dbTagPos, "version")
+	if len(versions) > 1 {
+		return nil, fmt.Errorf("struct %s has more than one field tagged version", rt.String())
+	}
+	if len(versions) == 1 {
+		field := rt.FieldByIndex(dbTagPos[versions[0]])
+		if !isIntegerKind(field.Type.Kind()) {
+			return nil, fmt.Errorf("version field %s of struct %s is not an integer", field.Name, rt.String())
+		}
+		ws.version = versions[0]
+	}
+
+	return ws, nil
+}
+
+//values returns the values of the fields of columns in the struct value rv
+//A nil pointer on the path to a field is written as NULL
+func (ws *writeStruct) values(rv reflect.Value, columns []string) ([]interface{}, error) {
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		fieldPos := ws.dbTagPos[column]
+		fieldVal, ok := mapping.FieldByIndex(rv, fieldPos)
+		if !ok {
+			continue
+		}
+
+		if !fieldVal.CanInterface() {
+			return nil, ErrUnexportedProperty{
+				PropertyName: ws.rt.FieldByIndex(fieldPos).Name,
+			}
+		}
+		values[i] = fieldVal.Interface()
+	}
+
+	return values, nil
+}
+
+//omitted reports whether column is tagged omitempty and holds a zero value in the struct value rv
+//A nil pointer on the path to the field counts as a zero value
+func (ws *writeStruct) omitted(rv reflect.Value, column string) bool {
+	fieldPos := ws.dbTagPos[column]
+	if !mapping.HasTagOption(ws.rt.FieldByIndex(fieldPos), "omitempty") {
+		return false
+	}
+
+	fieldVal, ok := mapping.FieldByIndex(rv, fieldPos)
+	return !ok || fieldVal.IsZero()
+}
+
+//insertValues returns the VALUES entries of columns for the struct value rv with placeholders numbered from start,
+//and the arguments of the placeholders. Omitted columns are written as DEFAULT without an argument
+func (ws *writeStruct) insertValues(rv reflect.Value, columns []string, start int) ([]string, []interface{}, error) {
+	values, err := ws.values(rv, columns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries := make([]string, len(columns))
+	args := make([]interface{}, 0, len(columns))
+	for i, column := range columns {
+		if ws.omitted(rv, column) {
+			entries[i] = "DEFAULT"
+			continue
+		}
+
+		args = append(args, values[i])
+		entries[i] = fmt.Sprintf("$%d", start+len(args)-1)
+	}
+
+	return entries, args, nil
+}
+
+//bumpVersion increments the version field of the struct value rv, as done by the database on update
+func (ws *writeStruct) bumpVersion(rv reflect.Value) {
+	fieldVal, ok := mapping.FieldByIndex(rv, ws.dbTagPos[ws.version])
+	if !ok || !fieldVal.CanSet() {
+		return
+	}
+
+	switch {
+	case fieldVal.CanInt():
+		fieldVal.SetInt(fieldVal.Int() + 1)
+	case fieldVal.CanUint():
+		fieldVal.SetUint(fieldVal.Uint() + 1)
+	}
+}
+
+//structValue returns the struct input points to
+func structValue(input interface{}) (reflect.Value, error) {
+	rv := reflect.ValueOf(input)
+	if !rv.IsValid() || rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return rv, fmt.Errorf("input value is not a pointer")
+	}
+
+	rv = rv.Elem()
+	if rv.Kind() != reflect.Struct {
+		return rv, fmt.Errorf("input value is not a pointer to a struct")
+	}
+
+	return rv, nil
+}
+
+//quoteColumns returns the quoted, comma separated columns, each prefixed by prefix when set
+func quoteColumns(columns []string, prefix string) string {
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = prefix + pgx.Identifier{column}.Sanitize()
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+func isIntegerKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+
+	return false
+}
+
+//contains reports whether columns has column
+func contains(columns []string, column string) bool {
+	for _, c := range columns {
+		if c == column {
+			return true
+		}
+	}
+
+	return false
+}